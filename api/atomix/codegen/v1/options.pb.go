@@ -0,0 +1,131 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: atomix/codegen/v1/options.proto
+
+package codegenv1
+
+import (
+	fmt "fmt"
+	proto "github.com/gogo/protobuf/proto"
+	descriptor "github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// OperationType is the type of a primitive operation
+type OperationType int32
+
+const (
+	// COMMAND is an operation that modifies the state of the primitive
+	OperationType_COMMAND OperationType = 0
+	// QUERY is an operation that reads the state of the primitive
+	OperationType_QUERY OperationType = 1
+)
+
+var OperationType_name = map[int32]string{
+	0: "COMMAND",
+	1: "QUERY",
+}
+
+var OperationType_value = map[string]int32{
+	"COMMAND": 0,
+	"QUERY":   1,
+}
+
+func (x OperationType) String() string {
+	return proto.EnumName(OperationType_name, int32(x))
+}
+
+func (OperationType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_53fbefd8834ece96, []int{0}
+}
+
+var E_PrimitiveType = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.ServiceOptions)(nil),
+	ExtensionType: (*string)(nil),
+	Field:         60000,
+	Name:          "atomix.codegen.v1.primitive_type",
+	Tag:           "bytes,60000,opt,name=primitive_type",
+	Filename:      "atomix/codegen/v1/options.proto",
+}
+
+var E_OperationType = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.MethodOptions)(nil),
+	ExtensionType: (*OperationType)(nil),
+	Field:         60100,
+	Name:          "atomix.codegen.v1.operation_type",
+	Tag:           "varint,60100,opt,name=operation_type,enum=atomix.codegen.v1.OperationType",
+	Filename:      "atomix/codegen/v1/options.proto",
+}
+
+var E_Async = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.MethodOptions)(nil),
+	ExtensionType: (*bool)(nil),
+	Field:         60101,
+	Name:          "atomix.codegen.v1.async",
+	Tag:           "varint,60101,opt,name=async",
+	Filename:      "atomix/codegen/v1/options.proto",
+}
+
+var E_Headers = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.FieldOptions)(nil),
+	ExtensionType: (*bool)(nil),
+	Field:         60200,
+	Name:          "atomix.codegen.v1.headers",
+	Tag:           "varint,60200,opt,name=headers",
+	Filename:      "atomix/codegen/v1/options.proto",
+}
+
+var E_PartitionKey = &proto.ExtensionDesc{
+	ExtendedType:  (*descriptor.FieldOptions)(nil),
+	ExtensionType: (*bool)(nil),
+	Field:         60201,
+	Name:          "atomix.codegen.v1.partition_key",
+	Tag:           "varint,60201,opt,name=partition_key",
+	Filename:      "atomix/codegen/v1/options.proto",
+}
+
+func init() {
+	proto.RegisterEnum("atomix.codegen.v1.OperationType", OperationType_name, OperationType_value)
+	proto.RegisterExtension(E_PrimitiveType)
+	proto.RegisterExtension(E_OperationType)
+	proto.RegisterExtension(E_Async)
+	proto.RegisterExtension(E_Headers)
+	proto.RegisterExtension(E_PartitionKey)
+}
+
+func init() { proto.RegisterFile("atomix/codegen/v1/options.proto", fileDescriptor_53fbefd8834ece96) }
+
+var fileDescriptor_53fbefd8834ece96 = []byte{
+	// 322 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x92, 0xc1, 0x4a, 0xeb, 0x40,
+	0x14, 0x86, 0x6f, 0xb9, 0xd4, 0xda, 0xd1, 0x94, 0x9a, 0x95, 0x08, 0xda, 0xee, 0x14, 0x17, 0x33,
+	0x54, 0x41, 0x68, 0xba, 0x52, 0xab, 0x08, 0x52, 0x8b, 0x51, 0x17, 0xba, 0x91, 0x34, 0x39, 0xa6,
+	0x07, 0xdb, 0x9e, 0x61, 0x32, 0x0d, 0xe6, 0x8d, 0xf4, 0x3d, 0xf4, 0x0d, 0xd4, 0xb5, 0x8f, 0x22,
+	0xc9, 0x34, 0x45, 0xcd, 0xa2, 0xbb, 0x19, 0xf8, 0xbf, 0xef, 0x3f, 0x87, 0x19, 0xd6, 0xf0, 0x34,
+	0x8d, 0xf1, 0x49, 0xf8, 0x14, 0x40, 0x08, 0x13, 0x11, 0xb7, 0x04, 0x49, 0x8d, 0x34, 0x89, 0xb8,
+	0x54, 0xa4, 0xc9, 0x5e, 0x33, 0x01, 0x3e, 0x0b, 0xf0, 0xb8, 0xb5, 0xd1, 0x0c, 0x89, 0xc2, 0x11,
+	0x88, 0x2c, 0x30, 0x98, 0x3e, 0x88, 0x00, 0x22, 0x5f, 0xa1, 0xd4, 0xa4, 0x0c, 0xb4, 0xbb, 0xcd,
+	0xac, 0xbe, 0x04, 0xe5, 0xa5, 0xa2, 0xeb, 0x44, 0x82, 0xbd, 0xc2, 0x2a, 0xc7, 0xfd, 0x5e, 0xef,
+	0xf0, 0xa2, 0x5b, 0xff, 0x67, 0x57, 0x59, 0xf9, 0xf2, 0xe6, 0xc4, 0xbd, 0xad, 0x97, 0x9c, 0x33,
+	0x56, 0x93, 0x0a, 0xc7, 0xa8, 0x31, 0x86, 0x7b, 0x9d, 0x26, 0x1b, 0xdc, 0xd8, 0x79, 0x6e, 0xe7,
+	0x57, 0xa0, 0x62, 0xf4, 0xa1, 0x6f, 0xc6, 0x5a, 0xff, 0x7a, 0xff, 0xdf, 0x2c, 0xed, 0x54, 0x5d,
+	0x6b, 0x0e, 0xa6, 0x0d, 0x0e, 0xb2, 0x1a, 0xe5, 0x95, 0xc6, 0xb4, 0x55, 0x30, 0xf5, 0x40, 0x0f,
+	0x29, 0xc8, 0x45, 0xaf, 0x1f, 0xa9, 0xa8, 0xb6, 0xd7, 0xe4, 0x85, 0x15, 0xf9, 0xaf, 0xe9, 0x5d,
+	0x8b, 0x7e, 0x5e, 0x9d, 0x03, 0x56, 0xf6, 0xa2, 0x64, 0xe2, 0x2f, 0x6c, 0x78, 0xcb, 0x1a, 0x96,
+	0x5d, 0x13, 0x77, 0xda, 0xac, 0x32, 0x04, 0x2f, 0x00, 0x15, 0xd9, 0x9b, 0x05, 0xf2, 0x14, 0x61,
+	0x34, 0x07, 0x9f, 0x3f, 0x0d, 0x98, 0xe7, 0x9d, 0x2e, 0xb3, 0xa4, 0xa7, 0x34, 0x66, 0xdb, 0x3d,
+	0x42, 0xb2, 0x48, 0xf0, 0x32, 0x13, 0xac, 0xce, 0xa9, 0x73, 0x48, 0x8e, 0x3a, 0x77, 0xed, 0x10,
+	0xf5, 0x70, 0x3a, 0xe0, 0x3e, 0x8d, 0xc5, 0x9f, 0x97, 0xf7, 0x24, 0x8a, 0xc2, 0x67, 0xe8, 0xcc,
+	0x8e, 0x71, 0x6b, 0xb0, 0x94, 0x35, 0xed, 0x7f, 0x0f, 0x00, 0x44, 0x5c, 0x0e, 0xd9, 0x32, 0x02,
+	0x00, 0x00,
+}
@@ -0,0 +1,12 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package codegenv1
+
+import _ "embed"
+
+// OptionsProto is the source of options.proto, for use as a protoc include
+//
+//go:embed options.proto
+var OptionsProto []byte
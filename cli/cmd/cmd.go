@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	client "github.com/atomix/codegen/client/cmd"
+	deps "github.com/atomix/codegen/deps/cmd"
+	docs "github.com/atomix/codegen/docs/cmd"
+	driver "github.com/atomix/codegen/driver/cmd"
+	example "github.com/atomix/codegen/example/cmd"
+	golang "github.com/atomix/codegen/go/cmd"
+	kubernetes "github.com/atomix/codegen/kubernetes/cmd"
+	"github.com/atomix/codegen/pkg/exec"
+	"github.com/atomix/codegen/pkg/version"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+// verbosity is the output verbosity set by the root command's persistent flag
+var verbosity = 1
+
+func GetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "atomix-codegen",
+		Short:             "Runs the Atomix code generators",
+		Version:           version.String(),
+		SilenceUsage:      true,
+		SilenceErrors:     true,
+		PersistentPreRunE: runRoot,
+	}
+	// Persistent flags have no shorthands to avoid conflicts with the flags of the mounted generators
+	cmd.PersistentFlags().String("config", "", "the path to the configuration file; the project configuration or, for the go and docs generators, the generator configuration")
+	cmd.PersistentFlags().String("work-dir", "", "the directory in which to run the generators")
+	cmd.PersistentFlags().Int("verbosity", 1, "the output verbosity; 0 disables command output")
+	_ = cmd.MarkPersistentFlagFilename("config")
+	_ = cmd.MarkPersistentFlagDirname("work-dir")
+
+	cmd.AddCommand(getGenerateCommand())
+	cmd.AddCommand(getVersionCommand())
+	cmd.AddCommand(client.GetCommand())
+	cmd.AddCommand(deps.GetCommand())
+	cmd.AddCommand(docs.GetCommand())
+	cmd.AddCommand(driver.GetCommand())
+	cmd.AddCommand(example.GetCommand())
+	cmd.AddCommand(golang.GetCommand())
+	cmd.AddCommand(kubernetes.GetCommand())
+	return cmd
+}
+
+func runRoot(cmd *cobra.Command, _ []string) error {
+	workDir, err := cmd.Flags().GetString("work-dir")
+	if err != nil {
+		return err
+	}
+	if workDir != "" {
+		if err := os.Chdir(workDir); err != nil {
+			return err
+		}
+	}
+
+	level, err := cmd.Flags().GetInt("verbosity")
+	if err != nil {
+		return err
+	}
+	verbosity = level
+	exec.SetVerbosity(level)
+	return nil
+}
@@ -0,0 +1,103 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	docs "github.com/atomix/codegen/docs/cmd"
+	golang "github.com/atomix/codegen/go/cmd"
+	kubernetes "github.com/atomix/codegen/kubernetes/cmd"
+	"github.com/atomix/codegen/pkg/generator/proto"
+	"github.com/atomix/codegen/pkg/generator/template"
+	"gopkg.in/yaml.v3"
+	"io/ioutil"
+)
+
+const defaultConfigFile = "atomix-codegen.yaml"
+
+func ParseConfigFile(path string) (Config, error) {
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	return ParseConfig(bytes)
+}
+
+func ParseConfig(bytes []byte) (Config, error) {
+	var config Config
+	if err := yaml.Unmarshal(bytes, &config); err != nil {
+		return config, err
+	}
+	return config, nil
+}
+
+// Config is the project configuration
+type Config struct {
+	Proto       ProtoConfig `yaml:"proto,omitempty"`
+	Boilerplate string      `yaml:"boilerplate,omitempty"`
+	Jobs        []JobConfig `yaml:"jobs,omitempty"`
+}
+
+// ProtoConfig is the Protobuf input configuration shared by all jobs
+type ProtoConfig struct {
+	Path    string   `yaml:"path,omitempty"`
+	Files   []string `yaml:"files,omitempty"`
+	Include []string `yaml:"include,omitempty"`
+}
+
+// override returns the configuration with any fields set in the given configuration replaced
+func (c ProtoConfig) override(config *ProtoConfig) ProtoConfig {
+	if config == nil {
+		return c
+	}
+	if config.Path != "" {
+		c.Path = config.Path
+	}
+	if config.Files != nil {
+		c.Files = config.Files
+	}
+	if config.Include != nil {
+		c.Include = config.Include
+	}
+	return c
+}
+
+// JobConfig is the configuration for a single generation job
+// Exactly one of the generator configurations must be set
+type JobConfig struct {
+	Name           string                   `yaml:"name,omitempty"`
+	Go             *GoJobConfig             `yaml:"go,omitempty"`
+	Docs           *DocsJobConfig           `yaml:"docs,omitempty"`
+	Templates      *TemplatesJobConfig      `yaml:"templates,omitempty"`
+	ProtoTemplates *ProtoTemplatesJobConfig `yaml:"protoTemplates,omitempty"`
+	Kubernetes     *kubernetes.Config       `yaml:"kubernetes,omitempty"`
+}
+
+// GoJobConfig is the configuration for a Go generation job
+type GoJobConfig struct {
+	Proto           *ProtoConfig `yaml:"proto,omitempty"`
+	golang.GoConfig `yaml:",inline"`
+}
+
+// DocsJobConfig is the configuration for a documentation generation job
+type DocsJobConfig struct {
+	Proto           *ProtoConfig `yaml:"proto,omitempty"`
+	docs.DocsConfig `yaml:",inline"`
+}
+
+// TemplatesJobConfig is the configuration for a template generation job
+type TemplatesJobConfig struct {
+	template.Config `yaml:",inline"`
+	Values          map[string]interface{} `yaml:"values,omitempty"`
+}
+
+// ProtoTemplatesJobConfig is the configuration for a Protobuf template generation job
+type ProtoTemplatesJobConfig struct {
+	Proto       *ProtoConfig           `yaml:"proto,omitempty"`
+	Mode        proto.Mode             `yaml:"mode,omitempty"`
+	Output      proto.OutputConfig     `yaml:"output,omitempty"`
+	Templates   []proto.TemplateConfig `yaml:"templates,omitempty"`
+	Values      map[string]interface{} `yaml:"values,omitempty"`
+	Boilerplate string                 `yaml:"boilerplate,omitempty"`
+}
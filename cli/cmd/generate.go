@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	docs "github.com/atomix/codegen/docs/cmd"
+	golang "github.com/atomix/codegen/go/cmd"
+	kubernetes "github.com/atomix/codegen/kubernetes/cmd"
+	"github.com/atomix/codegen/pkg/generator/output"
+	"github.com/atomix/codegen/pkg/generator/proto"
+	"github.com/atomix/codegen/pkg/generator/template"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+)
+
+func getGenerateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generate",
+		Short: "Runs the generation jobs in the project configuration",
+		Args:  cobra.NoArgs,
+		RunE:  runGenerate,
+	}
+	cmd.Flags().StringSliceP("job", "j", []string{}, "the names of the jobs to run; all jobs are run by default")
+	output.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func runGenerate(cmd *cobra.Command, _ []string) error {
+	configPath, err := cmd.Flags().GetString("config")
+	if err != nil {
+		return err
+	}
+	if configPath == "" {
+		configPath = defaultConfigFile
+	}
+	config, err := ParseConfigFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	// Paths in the configuration are relative to the directory containing the configuration file
+	if err := os.Chdir(filepath.Dir(configPath)); err != nil {
+		return err
+	}
+
+	outputMode, err := output.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	output.SetMode(outputMode)
+
+	jobNames, err := cmd.Flags().GetStringSlice("job")
+	if err != nil {
+		return err
+	}
+	selected := make(map[string]bool)
+	for _, name := range jobNames {
+		selected[name] = true
+	}
+
+	jobs := make(map[string]JobConfig)
+	var names []string
+	for i, job := range config.Jobs {
+		name := job.Name
+		if name == "" {
+			name = fmt.Sprintf("%d", i)
+		}
+		if _, ok := jobs[name]; ok {
+			return fmt.Errorf("duplicate job name %s", name)
+		}
+		jobs[name] = job
+		names = append(names, name)
+		delete(selected, name)
+	}
+	for name := range selected {
+		return fmt.Errorf("unknown job %s", name)
+	}
+
+	for _, name := range names {
+		if len(jobNames) > 0 && !contains(jobNames, name) {
+			continue
+		}
+		if verbosity > 0 {
+			fmt.Fprintf(cmd.OutOrStdout(), "Running job %s\n", name)
+		}
+		if err := runJob(config, jobs[name]); err != nil {
+			return fmt.Errorf("job %s: %w", name, err)
+		}
+	}
+	return output.Check()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func runJob(config Config, job JobConfig) error {
+	var count int
+	for _, set := range []bool{job.Go != nil, job.Docs != nil, job.Templates != nil, job.ProtoTemplates != nil, job.Kubernetes != nil} {
+		if set {
+			count++
+		}
+	}
+	if count != 1 {
+		return fmt.Errorf("must configure exactly one of go, docs, templates, protoTemplates or kubernetes")
+	}
+
+	// Jobs that prepend a license boilerplate to generated files default to the project's boilerplate
+	switch {
+	case job.Go != nil:
+		goJob := *job.Go
+		if goJob.Boilerplate == "" {
+			goJob.Boilerplate = config.Boilerplate
+		}
+		return runGoJob(config.Proto.override(job.Go.Proto), goJob)
+	case job.Docs != nil:
+		return runDocsJob(config.Proto.override(job.Docs.Proto), *job.Docs)
+	case job.Templates != nil:
+		templatesConfig := job.Templates.Config
+		if templatesConfig.Boilerplate == "" {
+			templatesConfig.Boilerplate = config.Boilerplate
+		}
+		return template.Generate(templatesConfig, job.Templates.Values)
+	case job.ProtoTemplates != nil:
+		protoTemplatesJob := *job.ProtoTemplates
+		if protoTemplatesJob.Boilerplate == "" {
+			protoTemplatesJob.Boilerplate = config.Boilerplate
+		}
+		return runProtoTemplatesJob(config.Proto.override(job.ProtoTemplates.Proto), protoTemplatesJob)
+	default:
+		kubernetesConfig := *job.Kubernetes
+		if kubernetesConfig.Boilerplate == "" {
+			kubernetesConfig.Boilerplate = config.Boilerplate
+		}
+		return kubernetes.Generate(kubernetesConfig)
+	}
+}
+
+func runGoJob(protoConfig ProtoConfig, job GoJobConfig) error {
+	config := golang.Config{
+		Proto: golang.ProtoConfig{
+			Files:   protoConfig.Files,
+			Include: protoConfig.Include,
+		},
+		Go: job.GoConfig,
+	}
+	if protoConfig.Path != "" {
+		config.Proto.Path = []string{protoConfig.Path}
+	}
+	return golang.Generate(config)
+}
+
+func runDocsJob(protoConfig ProtoConfig, job DocsJobConfig) error {
+	return docs.Generate(docs.Config{
+		Proto: docs.ProtoConfig{
+			Path:    protoConfig.Path,
+			Files:   protoConfig.Files,
+			Include: protoConfig.Include,
+		},
+		Docs: job.DocsConfig,
+	})
+}
+
+func runProtoTemplatesJob(protoConfig ProtoConfig, job ProtoTemplatesJobConfig) error {
+	return proto.Generate(proto.Config{
+		Mode: job.Mode,
+		Input: proto.InputConfig{
+			Path:    protoConfig.Path,
+			Files:   protoConfig.Files,
+			Include: protoConfig.Include,
+		},
+		Output:      job.Output,
+		Templates:   job.Templates,
+		Boilerplate: job.Boilerplate,
+	}, job.Values)
+}
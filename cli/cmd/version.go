@@ -0,0 +1,30 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"fmt"
+	"github.com/atomix/codegen/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+func getVersionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Prints the version of the code generators",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, _ []string) {
+			buildType := "development"
+			if version.IsRelease() {
+				buildType = "release"
+			} else if version.IsSnapshot() {
+				buildType = "snapshot"
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Version:    %s\n", version.Version())
+			fmt.Fprintf(cmd.OutOrStdout(), "Commit:     %s\n", version.Commit())
+			fmt.Fprintf(cmd.OutOrStdout(), "Build type: %s\n", buildType)
+		},
+	}
+}
@@ -4,12 +4,26 @@
 
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/atomix/codegen/pkg/generator/output"
+	"github.com/atomix/codegen/pkg/version"
+	"github.com/spf13/cobra"
+)
 
 func GetCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:  "atomix-gen-client",
-		RunE: run,
+		Use:     "client",
+		Short:   "Generates Go clients for Protobuf services",
+		Version: version.String(),
+		Args:    cobra.NoArgs,
+		RunE:    run,
 	}
+	cmd.Flags().StringP("input", "i", ".", "the input path")
+	cmd.Flags().StringSliceP("proto-files", "f", []string{"**/*.proto"}, "file name patterns by which to filter Protobuf sources")
+	cmd.Flags().StringP("output", "o", ".", "the output path")
+	cmd.Flags().Bool("in-process", false, "parse Protobuf sources and run the code generator in-process instead of invoking protoc")
+	cmd.Flags().String("templates", "", "a directory of templates overriding the built-in templates with the same names")
+	cmd.Flags().String("boilerplate", "", "the path to a license boilerplate to prepend to generated Go files")
+	output.AddFlags(cmd.Flags())
 	return cmd
 }
@@ -5,10 +5,87 @@
 package cmd
 
 import (
-	"errors"
+	"github.com/atomix/codegen/client/templates"
+	"github.com/atomix/codegen/pkg/generator"
+	"github.com/atomix/codegen/pkg/generator/output"
+	"github.com/atomix/codegen/pkg/generator/proto"
+	"github.com/atomix/codegen/pkg/generator/template"
 	"github.com/spf13/cobra"
 )
 
 func run(cmd *cobra.Command, args []string) error {
-	return errors.New("not implemented")
+	outputMode, err := output.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	output.SetMode(outputMode)
+
+	inputPath, err := cmd.Flags().GetString("input")
+	if err != nil {
+		return err
+	}
+
+	protoFiles, err := cmd.Flags().GetStringSlice("proto-files")
+	if err != nil {
+		return err
+	}
+
+	outputPath, err := cmd.Flags().GetString("output")
+	if err != nil {
+		return err
+	}
+
+	mode := proto.ProtocMode
+	inProcess, err := cmd.Flags().GetBool("in-process")
+	if err != nil {
+		return err
+	} else if inProcess {
+		mode = proto.InProcessMode
+	}
+
+	boilerplate, err := cmd.Flags().GetString("boilerplate")
+	if err != nil {
+		return err
+	}
+
+	overrideDir, err := cmd.Flags().GetString("templates")
+	if err != nil {
+		return err
+	}
+	loader := template.NewLoader(templates.FS, overrideDir)
+
+	config := generator.Config{
+		Generator: "client",
+		Config: template.Config{
+			Boilerplate: boilerplate,
+		},
+		Proto: &proto.Config{
+			Mode: mode,
+			Input: proto.InputConfig{
+				Path:  inputPath,
+				Files: protoFiles,
+			},
+			Output: proto.OutputConfig{
+				Path: outputPath,
+			},
+			Templates: []proto.TemplateConfig{
+				{
+					Name: "client.go",
+					Path: "client.go.tpl",
+					Output: proto.TemplateOutputConfig{
+						PathTemplate: "{{ .Service.File.Path | dir }}/{{ .Service.Name | toSnake }}_client.go",
+					},
+				},
+			},
+		},
+	}
+
+	if err := loader.Load(config.Sources()...); err != nil {
+		return err
+	}
+
+	if err := generator.Generate(config, nil); err != nil {
+		return err
+	}
+	return output.Check()
 }
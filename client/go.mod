@@ -1,12 +0,0 @@
-module github.com/atomix/codegen/client
-
-go 1.18
-
-require github.com/spf13/cobra v1.4.0
-
-require (
-	github.com/inconshreveable/mousetrap v1.0.0 // indirect
-	github.com/spf13/pflag v1.0.5 // indirect
-)
-
-replace github.com/atomix/codegen => ../
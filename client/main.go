@@ -1,19 +0,0 @@
-// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
-//
-// SPDX-License-Identifier: Apache-2.0
-
-package main
-
-import (
-	"fmt"
-	"github.com/atomix/codegen/client/cmd"
-	"os"
-)
-
-func main() {
-	cmd := cmd.GetCommand()
-	if err := cmd.Execute(); err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-}
@@ -0,0 +1,157 @@
+{{- $proxy := printf "%sProxy" .Service.Name -}}
+package {{ .Service.Package.Name }}
+
+import (
+	"context"
+	"github.com/atomix/sdk/pkg/errors"
+	"google.golang.org/grpc"
+	"io"
+)
+
+// New{{ $proxy }} creates a new {{ .Service.Name }} client proxy for the given connection
+func New{{ $proxy }}(conn *grpc.ClientConn) *{{ $proxy }} {
+	return &{{ $proxy }}{
+		client: New{{ .Service.Name }}Client(conn),
+	}
+}
+
+// {{ $proxy }} is a typed client for the {{ .Service.Name }} service
+type {{ $proxy }} struct {
+	client {{ .Service.Name }}Client
+}
+{{- range .Service.Methods }}
+{{ if and .Request.IsUnary .Response.IsUnary }}
+// {{ .Name }} sends a {{ .Request.Type.Name }} and returns the {{ .Response.Type.Name }}
+func (p *{{ $proxy }}) {{ .Name }}(ctx context.Context, request *{{ .Request.Type.Name }}, opts ...grpc.CallOption) (*{{ .Response.Type.Name }}, error) {
+	response, err := p.client.{{ .Name }}(ctx, request, opts...)
+	if err != nil {
+		return nil, errors.FromProto(err)
+	}
+	return response, nil
+}
+{{- else if .Request.IsUnary }}
+// {{ .Name }} sends a {{ .Request.Type.Name }} and streams {{ .Response.Type.Name }}s to the given channel.
+// The channel is closed when the stream ends, after any error has been sent to the errs channel, so errs
+// should be buffered.
+func (p *{{ $proxy }}) {{ .Name }}(ctx context.Context, request *{{ .Request.Type.Name }}, ch chan<- *{{ .Response.Type.Name }}, errs chan<- error, opts ...grpc.CallOption) error {
+	stream, err := p.client.{{ .Name }}(ctx, request, opts...)
+	if err != nil {
+		return errors.FromProto(err)
+	}
+	go func() {
+		defer close(ch)
+		for {
+			response, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				select {
+				case errs <- errors.FromProto(err):
+				case <-ctx.Done():
+				}
+				return
+			}
+			select {
+			case ch <- response:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+{{- else if .Response.IsUnary }}
+// {{ .Name }} streams {{ .Request.Type.Name }}s from the given channel and returns the {{ .Response.Type.Name }}
+func (p *{{ $proxy }}) {{ .Name }}(ctx context.Context, ch <-chan *{{ .Request.Type.Name }}, opts ...grpc.CallOption) (*{{ .Response.Type.Name }}, error) {
+	stream, err := p.client.{{ .Name }}(ctx, opts...)
+	if err != nil {
+		return nil, errors.FromProto(err)
+	}
+	for done := false; !done; {
+		select {
+		case request, ok := <-ch:
+			if !ok {
+				done = true
+				break
+			}
+			// io.EOF means the server ended the stream; its status is returned by CloseAndRecv
+			if err := stream.Send(request); err == io.EOF {
+				done = true
+			} else if err != nil {
+				return nil, errors.FromProto(err)
+			}
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	response, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, errors.FromProto(err)
+	}
+	return response, nil
+}
+{{- else }}
+// {{ .Name }} streams {{ .Request.Type.Name }}s from the given channel and streams {{ .Response.Type.Name }}s to the given channel.
+// The responses channel is closed when the stream ends. Errors are sent to the errs channel, which should be
+// buffered to hold both a send and a receive error.
+func (p *{{ $proxy }}) {{ .Name }}(ctx context.Context, requests <-chan *{{ .Request.Type.Name }}, responses chan<- *{{ .Response.Type.Name }}, errs chan<- error, opts ...grpc.CallOption) error {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := p.client.{{ .Name }}(ctx, opts...)
+	if err != nil {
+		cancel()
+		return errors.FromProto(err)
+	}
+	go func() {
+		for {
+			select {
+			case request, ok := <-requests:
+				if !ok {
+					_ = stream.CloseSend()
+					return
+				}
+				// io.EOF means the server ended the stream; its status is received by Recv
+				if err := stream.Send(request); err == io.EOF {
+					return
+				} else if err != nil {
+					select {
+					case errs <- errors.FromProto(err):
+					case <-ctx.Done():
+					}
+					cancel()
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		defer cancel()
+		defer close(responses)
+		for {
+			response, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				// Errors caused by canceling the stream have already been reported or were requested by the caller
+				if ctx.Err() == nil {
+					select {
+					case errs <- errors.FromProto(err):
+					case <-ctx.Done():
+					}
+				}
+				return
+			}
+			select {
+			case responses <- response:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+{{- end }}
+{{- end }}
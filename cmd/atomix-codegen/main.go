@@ -6,14 +6,14 @@ package main
 
 import (
 	"fmt"
-	"github.com/atomix/codegen/docs/cmd"
+	"github.com/atomix/codegen/cli/cmd"
 	"os"
 )
 
 func main() {
 	cmd := cmd.GetCommand()
 	if err := cmd.Execute(); err != nil {
-		fmt.Println(err)
+		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
@@ -1,108 +0,0 @@
-// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
-//
-// SPDX-License-Identifier: Apache-2.0
-
-package internal
-
-import (
-	"github.com/gogo/protobuf/gogoproto"
-	gogoprotobuf "github.com/gogo/protobuf/proto"
-	"github.com/golang/protobuf/proto" //nolint:staticcheck
-	"github.com/golang/protobuf/protoc-gen-go/descriptor"
-	pgs "github.com/lyft/protoc-gen-star"
-)
-
-// getEmbed gets the embed extension from the given field
-func getEmbed(field pgs.Field) (*bool, error) {
-	var embed bool
-	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Embed), &embed)
-	if err != nil {
-		return nil, err
-	} else if !ok {
-		return nil, nil
-	}
-	return &embed, nil
-}
-
-// getCastType gets the casttype extension from the given field
-func getCastType(field pgs.Field) (*string, error) {
-	var castType string
-	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Casttype), &castType)
-	if err != nil {
-		return nil, err
-	} else if !ok {
-		return nil, nil
-	}
-	return &castType, nil
-}
-
-// getCastKey gets the castkey extension from the given field
-func getCastKey(field pgs.Field) (*string, error) {
-	var castKey string
-	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Castkey), &castKey)
-	if err != nil {
-		return nil, err
-	} else if !ok {
-		return nil, nil
-	}
-	return &castKey, nil
-}
-
-// getCastValue gets the castvalue extension from the given field
-func getCastValue(field pgs.Field) (*string, error) {
-	var castValue string
-	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Castvalue), &castValue)
-	if err != nil {
-		return nil, err
-	} else if !ok {
-		return nil, nil
-	}
-	return &castValue, nil
-}
-
-// getCustomName gets the customname extension from the given field
-func getCustomName(field pgs.Field) (*string, error) {
-	var customName string
-	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Customname), &customName)
-	if err != nil {
-		return nil, err
-	} else if !ok {
-		return nil, nil
-	}
-	return &customName, nil
-}
-
-// getCustomType gets the customtype extension from the given field
-func getCustomType(field pgs.Field) (*string, error) {
-	var customType string
-	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Customtype), &customType)
-	if err != nil {
-		return nil, err
-	} else if !ok {
-		return nil, nil
-	}
-	return &customType, nil
-}
-
-// getNullable gets the nullable extension from the given field
-func getNullable(field pgs.Field) (*bool, error) {
-	var nullable bool
-	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Nullable), &nullable)
-	if err != nil {
-		return nil, err
-	} else if !ok {
-		return nil, nil
-	}
-	return &nullable, nil
-}
-
-func getExtensionDesc(extension *gogoprotobuf.ExtensionDesc) *proto.ExtensionDesc {
-	return &proto.ExtensionDesc{
-		ExtendedType:  (*descriptor.FieldOptions)(nil),
-		ExtensionType: extension.ExtensionType,
-		Field:         extension.Field,
-		Name:          extension.Name,
-		Tag:           extension.Tag,
-		Filename:      extension.Filename,
-	}
-}
@@ -5,12 +5,12 @@
 package main
 
 import (
-	"github.com/atomix/codegen/cmd/protoc-gen-service/internal"
+	"github.com/atomix/codegen/pkg/generator/proto/plugin"
 	"github.com/lyft/protoc-gen-star"
 )
 
 func main() {
 	pgs.Init(pgs.DebugMode()).
-		RegisterModule(internal.NewModule()).
+		RegisterModule(plugin.NewModule()).
 		Render()
 }
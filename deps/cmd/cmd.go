@@ -10,9 +10,10 @@ import (
 
 func GetCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:  "atomix-gen-deps",
-		Args: cobra.NoArgs,
-		RunE: run,
+		Use:   "deps",
+		Short: "Updates Go module dependencies to match an Atomix runtime version",
+		Args:  cobra.NoArgs,
+		RunE:  run,
 	}
 	cmd.Flags().BoolP("check", "c", false, "check module compatibility only")
 	cmd.Flags().StringP("version", "v", "", "the target runtime API version")
@@ -5,6 +5,8 @@
 package cmd
 
 import (
+	"github.com/atomix/codegen/pkg/generator/output"
+	"github.com/atomix/codegen/pkg/version"
 	"github.com/spf13/cobra"
 )
 
@@ -12,15 +14,16 @@ func GetCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "docs",
 		Short:   "Generates documentation from Protobuf sources",
+		Version: version.String(),
 		Aliases: []string{"doc"},
 		Args:    cobra.NoArgs,
 		RunE:    run,
 	}
-	cmd.Flags().StringP("config", "c", "", "the path to the generator configuration")
 	cmd.Flags().StringP("proto-path", "p", ".", "the relative path to the Protobuf API root")
 	cmd.Flags().StringSliceP("proto-pattern", "f", []string{"**/*.proto"}, "a pattern by which to filter Protobuf sources")
+	cmd.Flags().StringSlice("proto-include", []string{}, "additional paths from which to resolve Protobuf imports")
 	cmd.Flags().StringP("docs-path", "d", ".", "the relative path to the documentation root")
 	cmd.Flags().String("docs-format", "markdown", "the documentation format")
-	_ = cmd.MarkFlagFilename("config")
+	output.AddFlags(cmd.Flags())
 	return cmd
 }
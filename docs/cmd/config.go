@@ -31,8 +31,9 @@ type Config struct {
 }
 
 type ProtoConfig struct {
-	Path  string   `yaml:"path,omitempty"`
-	Files []string `yaml:"files,omitempty"`
+	Path    string   `yaml:"path,omitempty"`
+	Files   []string `yaml:"files,omitempty"`
+	Include []string `yaml:"include,omitempty"`
 }
 
 type DocsConfig struct {
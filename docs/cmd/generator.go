@@ -7,6 +7,8 @@ package cmd
 import (
 	"fmt"
 	"github.com/atomix/codegen/pkg/exec"
+	"github.com/atomix/codegen/pkg/generator/output"
+	"github.com/atomix/codegen/pkg/generator/proto/include"
 	"github.com/bmatcuk/doublestar/v4"
 	"io/fs"
 	"os"
@@ -28,10 +30,18 @@ func NewGenerator(config Config) *Generator {
 }
 
 type Generator struct {
-	Config Config
+	Config     Config
+	includeDir string
 }
 
 func (g *Generator) Generate() error {
+	includeDir, err := include.TempDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(includeDir)
+	g.includeDir = includeDir
+
 	for _, pattern := range g.Config.Proto.Files {
 		if err := NewGlob(g, pattern).Generate(); err != nil {
 			return err
@@ -44,19 +54,18 @@ func (g *Generator) gen(file string, spec Spec) error {
 	var path []string
 	path = append(path, ".")
 	path = append(path, g.Config.Proto.Path)
-	path = append(path, filepath.Join(os.Getenv("GOPATH"), "src/github.com/gogo/protobuf"))
-
-	var args []string
-	args = append(args, "-I", strings.Join(path, ":"))
-	docDir := filepath.Dir(filepath.Join(g.Config.Docs.Path, file))
-	if err := os.MkdirAll(docDir, 0755); err != nil {
-		return err
-	}
-	args = append(args, fmt.Sprintf("--doc_out=%s", docDir))
-	args = append(args, fmt.Sprintf("--doc_opt=%s", spec.String()))
-	args = append(args, file)
-
-	return exec.Run("protoc", args...)
+	path = append(path, g.Config.Proto.Include...)
+	path = append(path, g.includeDir)
+
+	outputPath := filepath.Dir(filepath.Join(g.Config.Docs.Path, file))
+	return output.GenerateDir(outputPath, output.AlwaysPolicy, func(outDir string) error {
+		var args []string
+		args = append(args, "-I", strings.Join(path, ":"))
+		args = append(args, fmt.Sprintf("--doc_out=%s", outDir))
+		args = append(args, fmt.Sprintf("--doc_opt=%s", spec.String()))
+		args = append(args, file)
+		return exec.Run("protoc", args...)
+	})
 }
 
 func NewGlob(generator *Generator, pattern string) *GlobGenerator {
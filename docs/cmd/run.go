@@ -5,11 +5,17 @@
 package cmd
 
 import (
+	"github.com/atomix/codegen/pkg/generator/output"
 	"github.com/spf13/cobra"
-	"os"
 )
 
 func run(cmd *cobra.Command, args []string) error {
+	outputMode, err := output.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	output.SetMode(outputMode)
+
 	var config Config
 	configPath, err := cmd.Flags().GetString("config")
 	if err != nil {
@@ -35,11 +41,13 @@ func run(cmd *cobra.Command, args []string) error {
 	}
 	config.Proto.Files = protoPatterns
 
-	docsPath, err := cmd.Flags().GetString("docs-path")
+	protoIncludes, err := cmd.Flags().GetStringSlice("proto-include")
 	if err != nil {
 		return err
 	}
-	err = os.MkdirAll(docsPath, 0755)
+	config.Proto.Include = append(config.Proto.Include, protoIncludes...)
+
+	docsPath, err := cmd.Flags().GetString("docs-path")
 	if err != nil {
 		return err
 	}
@@ -50,5 +58,8 @@ func run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	config.Docs.Format = format
-	return Generate(config)
+	if err := Generate(config); err != nil {
+		return err
+	}
+	return output.Check()
 }
@@ -5,23 +5,37 @@
 package cmd
 
 import (
+	"github.com/atomix/codegen/pkg/generator/output"
+	"github.com/atomix/codegen/pkg/version"
 	"github.com/spf13/cobra"
 )
 
 func GetCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:  "atomix-gen-driver",
-		Args: cobra.NoArgs,
-		RunE: run,
+		Use:     "driver",
+		Short:   "Generates an Atomix driver module",
+		Version: version.String(),
+		Args:    cobra.NoArgs,
+		RunE:    run,
 	}
 	cmd.Flags().StringP("name", "n", "", "the driver name")
-	cmd.Flags().StringP("api-verison", "v", "v1", "the driver API version")
+	cmd.Flags().StringP("api-version", "v", "v1", "the driver API version")
+	cmd.Flags().StringP("runtime-version", "r", "", "the target runtime version")
 	cmd.Flags().StringP("module-path", "p", "", "the driver module path")
 	cmd.Flags().String("github-owner", "", "the GitHub user to which to publish release artifacts")
 	cmd.Flags().String("github-repo", "", "the GitHub repo to which to publish release artifacts")
 	cmd.Flags().StringP("input", "i", ".", "the input path")
 	cmd.Flags().StringP("output", "o", ".", "the output path")
+	cmd.Flags().Bool("in-process", false, "parse Protobuf sources and run the code generator in-process instead of invoking protoc")
+	cmd.Flags().String("templates", "", "a directory of templates overriding the built-in templates with the same names")
+	cmd.Flags().String("boilerplate", "", "the path to a license boilerplate to prepend to generated Go files")
+	output.AddFlags(cmd.Flags())
+	// The module is only tidied once the generated sources have been written
+	for _, name := range []string{"dry-run", "diff", "check"} {
+		cmd.Flags().Lookup(name).Usage += " (go.mod and go.sum are not tidied or checked)"
+	}
 	_ = cmd.MarkFlagRequired("name")
 	_ = cmd.MarkFlagRequired("module-path")
+	_ = cmd.MarkFlagRequired("runtime-version")
 	return cmd
 }
@@ -5,18 +5,24 @@
 package cmd
 
 import (
+	"github.com/atomix/codegen/driver/templates"
 	"github.com/atomix/codegen/pkg/exec"
 	"github.com/atomix/codegen/pkg/generator"
+	"github.com/atomix/codegen/pkg/generator/output"
 	"github.com/atomix/codegen/pkg/generator/proto"
 	"github.com/atomix/codegen/pkg/generator/template"
+	"github.com/iancoleman/strcase"
 	"github.com/spf13/cobra"
-	"os"
 	"path/filepath"
 )
 
-const protoExt = ".proto"
-
 func run(cmd *cobra.Command, args []string) error {
+	outputMode, err := output.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	output.SetMode(outputMode)
+
 	var context Context
 
 	name, err := cmd.Flags().GetString("name")
@@ -31,6 +37,12 @@ func run(cmd *cobra.Command, args []string) error {
 	}
 	context.Driver.APIVersion = apiVersion
 
+	runtimeVersion, err := cmd.Flags().GetString("runtime-version")
+	if err != nil {
+		return err
+	}
+	context.Runtime.Version = runtimeVersion
+
 	inputPath, err := cmd.Flags().GetString("input")
 	if err != nil {
 		return err
@@ -41,6 +53,14 @@ func run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	mode := proto.ProtocMode
+	inProcess, err := cmd.Flags().GetBool("in-process")
+	if err != nil {
+		return err
+	} else if inProcess {
+		mode = proto.InProcessMode
+	}
+
 	path, err := cmd.Flags().GetString("module-path")
 	if err != nil {
 		return err
@@ -59,48 +79,68 @@ func run(cmd *cobra.Command, args []string) error {
 	}
 	context.Repo.Name = repoName
 
+	boilerplate, err := cmd.Flags().GetString("boilerplate")
+	if err != nil {
+		return err
+	}
+
+	overrideDir, err := cmd.Flags().GetString("templates")
+	if err != nil {
+		return err
+	}
+	loader := template.NewLoader(templates.FS, overrideDir).Scaffold(".gitignore.tpl", ".goreleaser.yaml.tpl", "Makefile.tpl", "go.mod.tpl")
+
 	config := generator.Config{
 		Generator: "driver",
 		Config: template.Config{
+			Boilerplate: boilerplate,
 			Templates: []template.TemplateConfig{
 				{
 					Name: ".gitignore",
-					Path: getTemplatePath(".gitignore.tpl"),
+					Path: ".gitignore.tpl",
 					Output: template.OutputConfig{
 						Path: filepath.Join(outputPath, ".gitignore"),
 					},
 				},
 				{
 					Name: ".goreleaser.yaml",
-					Path: getTemplatePath(".goreleaser.yaml.tpl"),
+					Path: ".goreleaser.yaml.tpl",
 					Output: template.OutputConfig{
 						Path: filepath.Join(outputPath, ".goreleaser.yaml"),
 					},
 				},
 				{
 					Name: "Makefile",
-					Path: getTemplatePath("Makefile.tpl"),
+					Path: "Makefile.tpl",
 					Output: template.OutputConfig{
 						Path: filepath.Join(outputPath, "Makefile"),
 					},
 				},
 				{
 					Name: "go.mod",
-					Path: getTemplatePath("go.mod.tpl"),
+					Path: "go.mod.tpl",
 					Output: template.OutputConfig{
 						Path: filepath.Join(outputPath, "go.mod"),
 					},
 				},
 				{
 					Name: "driver.go",
-					Path: getTemplatePath("driver.go.tpl"),
+					Path: "driver.go.tpl",
 					Output: template.OutputConfig{
 						Path: filepath.Join(outputPath, "driver/driver.go"),
 					},
 				},
+				{
+					Name: "main.go",
+					Path: "main.go.tpl",
+					Output: template.OutputConfig{
+						Path: filepath.Join(outputPath, "cmd", strcase.ToKebab(name), "main.go"),
+					},
+				},
 			},
 		},
 		Proto: &proto.Config{
+			Mode: mode,
 			Input: proto.InputConfig{
 				Path: inputPath,
 			},
@@ -110,20 +150,29 @@ func run(cmd *cobra.Command, args []string) error {
 			Templates: []proto.TemplateConfig{
 				{
 					Name: "primitive.go",
-					Path: getTemplatePath("primitive.go.tpl"),
+					Path: "primitive.go.tpl",
 					Output: proto.TemplateOutputConfig{
-						PathTemplate: "driver/{{ .Atom | toSnake }}.go",
+						PathTemplate: "driver/{{ .Service.Name | toSnake }}.go",
 					},
 				},
 			},
 		},
 	}
 
+	if err := loader.Load(config.Sources()...); err != nil {
+		return err
+	}
+
 	err = generator.Generate(config, context)
 	if err != nil {
 		return err
 	}
 
+	// The module can only be tidied once the generated sources have been written, so go.mod and go.sum
+	// are neither tidied nor checked in the other output modes
+	if outputMode != output.WriteMode {
+		return output.Check()
+	}
 	err = exec.RunIn(outputPath, "go", "mod", "tidy")
 	if err != nil {
 		return err
@@ -132,9 +181,10 @@ func run(cmd *cobra.Command, args []string) error {
 }
 
 type Context struct {
-	Driver DriverContext
-	Module ModuleContext
-	Repo   RepoContext
+	Driver  DriverContext
+	Module  ModuleContext
+	Repo    RepoContext
+	Runtime RuntimeContext
 }
 
 type DriverContext struct {
@@ -151,15 +201,6 @@ type RepoContext struct {
 	Name  string
 }
 
-func apply(templatePath string, outputPath string, context Context) error {
-	tpl := template.New(templatePath)
-	file, err := os.Create(outputPath)
-	if err != nil {
-		return err
-	}
-	return tpl.Execute(file, context)
-}
-
-func getTemplatePath(name string) string {
-	return filepath.Join("/templates/", name)
+type RuntimeContext struct {
+	Version string
 }
@@ -1,15 +1,15 @@
-project_name: {{ .Driver.Name | toSnake }}_driver
+project_name: {{ .Values.Driver.Name | toSnake }}_driver
 
 before:
   hooks:
     - go mod tidy
-    - atomix gen deps --version {{ .Runtime.Version }}
+    - atomix-codegen deps --version {{ .Values.Runtime.Version }}
     - go mod tidy
 
 builds:
   - id: plugin
-    main: ./cmd/{{ .Driver.Name | toKebab }}
-    binary: {{ .Driver.Name | toKebab }}-{{ "{{ .Version }}" }}.{{ .Runtime.Version }}.so
+    main: ./cmd/{{ .Values.Driver.Name | toKebab }}
+    binary: {{ .Values.Driver.Name | toKebab }}-{{ "{{ .Version }}" }}.{{ .Values.Runtime.Version }}.so
     goos:
       - linux
     goarch:
@@ -24,7 +24,7 @@ builds:
     gcflags:
       - all=-N -l
     ldflags:
-      - -s -w -X ./plugin/{{ .Driver.Name | toKebab }}-driver.version={{ "{{ .Version }}" }} -X ./plugin/{{ .Driver.Name | toKebab }}-driver.commit={{ "{{ .Commit }}" }}
+      - -s -w -X {{ .Values.Module.Path }}/driver.version={{ "{{ .Version }}" }} -X {{ .Values.Module.Path }}/driver.commit={{ "{{ .Commit }}" }}
 
 checksum:
   name_template: 'checksums.txt'
@@ -38,11 +38,11 @@ changelog:
     exclude:
       - '^docs:'
 
-{{- if .Repo.Name }}
+{{- if .Values.Repo.Name }}
 release:
   github:
-    owner: {{ .Repo.Owner }}
-    name: {{ .Repo.Name }}
+    owner: {{ .Values.Repo.Owner }}
+    name: {{ .Values.Repo.Name }}
   prerelease: auto
   draft: true
 {{- end }}
@@ -15,17 +15,16 @@ build:
 
 api-go:
 	@cd api && (rm -r **/*.pb.go || true) && cd ..
-	atomix gen go \
+	atomix-codegen go \
 	    --proto-path ./api \
-	    --package github.com/{{ .Repo.Owner }}/{{ .Repo.Name }}/api \
-	    --output-path ./api
+	    --import-path {{ .Values.Module.Path }}/api \
+	    --go-path ./api
 
 api-docs:
 	@cd api && (rm -r **/*.md || true) && cd ..
-	atomix gen docs \
+	atomix-codegen docs \
 	    --proto-path ./api \
-	    --package github.com/{{ .Repo.Owner }}/{{ .Repo.Name }}/api \
-	    --output-path ./api
+	    --docs-path ./api
 
 api: api-go api-docs
 
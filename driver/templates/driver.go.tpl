@@ -0,0 +1,81 @@
+package driver
+
+import (
+	"context"
+	driverapi "github.com/atomix/sdk/pkg/driver/{{ .Values.Driver.APIVersion }}"
+	"google.golang.org/grpc"
+	// atomix-codegen:begin-user-code imports
+	// atomix-codegen:end-user-code imports
+)
+
+var (
+	version string
+	commit  string
+)
+
+// primitives is the set of primitive servers registered with the driver
+var primitives []func(*grpc.Server, *Conn)
+
+// registerPrimitive registers a primitive server with the driver
+func registerPrimitive(register func(*grpc.Server, *Conn)) {
+	primitives = append(primitives, register)
+}
+
+// New creates a new {{ .Values.Driver.Name }} driver
+func New() driverapi.Driver {
+	return &Driver{}
+}
+
+// Driver is the {{ .Values.Driver.Name }} driver
+type Driver struct{}
+
+// Name returns the driver name
+func (d *Driver) Name() string {
+	return "{{ .Values.Driver.Name }}"
+}
+
+// Version returns the driver version
+func (d *Driver) Version() string {
+	return version
+}
+
+// Commit returns the commit from which the driver was built
+func (d *Driver) Commit() string {
+	return commit
+}
+
+// Connect connects to the store with the given configuration
+func (d *Driver) Connect(ctx context.Context, config []byte) (driverapi.Conn, error) {
+	// atomix-codegen:begin-user-code Connect
+	// TODO: connect to the store
+	return &Conn{
+		config: config,
+	}, nil
+	// atomix-codegen:end-user-code Connect
+}
+
+// Conn is a connection to the store
+type Conn struct {
+	config []byte
+	// atomix-codegen:begin-user-code Conn
+	// atomix-codegen:end-user-code Conn
+}
+
+// Register registers the driver's primitive servers with the given gRPC server
+func (c *Conn) Register(server *grpc.Server) error {
+	for _, register := range primitives {
+		register(server, c)
+	}
+	return nil
+}
+
+// Close closes the connection
+func (c *Conn) Close(ctx context.Context) error {
+	// atomix-codegen:begin-user-code Close
+	// TODO: close the connection to the store
+	return nil
+	// atomix-codegen:end-user-code Close
+}
+
+var _ driverapi.Driver = (*Driver)(nil)
+var _ driverapi.Conn = (*Conn)(nil)
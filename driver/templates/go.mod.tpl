@@ -1,7 +1,7 @@
-module {{ .Module.Path }}
+module {{ .Values.Module.Path }}
 
 go 1.18
 
 require (
-    github.com/atomix/sdk {{ .Runtime.Version }}
+    github.com/atomix/sdk {{ .Values.Runtime.Version }}
 )
\ No newline at end of file
@@ -0,0 +1,8 @@
+package main
+
+import (
+	"{{ .Values.Module.Path }}/driver"
+)
+
+// Plugin is the symbol the runtime looks up to load the driver
+var Plugin = driver.New()
@@ -0,0 +1,62 @@
+{{- $pkg := import .Service.Package.Path .Service.Package.Name -}}
+{{- $server := printf "%sServer" (.Service.Name | toLowerCamel) -}}
+{{- $unary := false -}}
+{{- range .Service.Methods -}}
+{{- if and .Request.IsUnary .Response.IsUnary -}}{{- $unary = true -}}{{- end -}}
+{{- end -}}
+package driver
+
+import (
+{{- if $unary }}
+	"context"
+{{- end }}
+	"google.golang.org/grpc"
+	// atomix-codegen:begin-user-code imports
+	// atomix-codegen:end-user-code imports
+)
+
+func init() {
+	registerPrimitive(func(server *grpc.Server, conn *Conn) {
+		{{ $pkg }}.Register{{ .Service.Name }}Server(server, new{{ .Service.Name }}Server(conn))
+	})
+}
+
+// new{{ .Service.Name }}Server creates a new {{ .Service.Name }} server for the given connection
+func new{{ .Service.Name }}Server(conn *Conn) {{ $pkg }}.{{ .Service.Name }}Server {
+	return &{{ $server }}{
+		conn: conn,
+	}
+}
+
+// {{ $server }} implements the {{ .Service.Name }} service
+type {{ $server }} struct {
+	conn *Conn
+	// atomix-codegen:begin-user-code {{ $server }}
+	// atomix-codegen:end-user-code {{ $server }}
+}
+{{- range .Service.Methods }}
+{{ if and .Request.IsUnary .Response.IsUnary }}
+func (s *{{ $server }}) {{ .Name }}(ctx context.Context, request *{{ goType .Request.Type }}) (*{{ goType .Response.Type }}, error) {
+	// atomix-codegen:begin-user-code {{ .Name }}
+	// TODO: implement {{ .Name }}
+	return nil, {{ qualify "google.golang.org/grpc/status" "Error" }}({{ qualify "google.golang.org/grpc/codes" "Unimplemented" }}, "{{ .Name }} not implemented")
+	// atomix-codegen:end-user-code {{ .Name }}
+}
+{{- else if .Request.IsUnary }}
+func (s *{{ $server }}) {{ .Name }}(request *{{ goType .Request.Type }}, stream {{ $pkg }}.{{ $.Service.Name }}_{{ .Name }}Server) error {
+	// atomix-codegen:begin-user-code {{ .Name }}
+	// TODO: implement {{ .Name }}
+	return {{ qualify "google.golang.org/grpc/status" "Error" }}({{ qualify "google.golang.org/grpc/codes" "Unimplemented" }}, "{{ .Name }} not implemented")
+	// atomix-codegen:end-user-code {{ .Name }}
+}
+{{- else }}
+func (s *{{ $server }}) {{ .Name }}(stream {{ $pkg }}.{{ $.Service.Name }}_{{ .Name }}Server) error {
+	// atomix-codegen:begin-user-code {{ .Name }}
+	// TODO: implement {{ .Name }}
+	return {{ qualify "google.golang.org/grpc/status" "Error" }}({{ qualify "google.golang.org/grpc/codes" "Unimplemented" }}, "{{ .Name }} not implemented")
+	// atomix-codegen:end-user-code {{ .Name }}
+}
+{{- end }}
+{{- end }}
+
+var _ {{ $pkg }}.{{ .Service.Name }}Server = (*{{ $server }})(nil)
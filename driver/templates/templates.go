@@ -0,0 +1,13 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package templates contains the built-in templates of the driver generator
+package templates
+
+import "embed"
+
+// FS contains the built-in templates
+//
+//go:embed *.tpl
+var FS embed.FS
@@ -5,18 +5,26 @@
 package cmd
 
 import (
+	"github.com/atomix/codegen/pkg/generator/output"
+	"github.com/atomix/codegen/pkg/version"
 	"github.com/spf13/cobra"
 )
 
 func GetCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:  "atomix-gen-example",
-		Args: cobra.NoArgs,
-		RunE: run,
+		Use:     "example",
+		Short:   "Generates example code for Atomix primitives",
+		Version: version.String(),
+		Args:    cobra.NoArgs,
+		RunE:    run,
 	}
 	cmd.Flags().StringP("input", "i", ".", "the input path")
 	cmd.Flags().String("repo-url", "", "the input repo URL")
 	cmd.Flags().String("repo-tag", "", "the input repo tag")
 	cmd.Flags().StringP("output", "o", ".", "the output path")
+	cmd.Flags().Bool("in-process", false, "parse Protobuf sources and run the code generator in-process instead of invoking protoc")
+	cmd.Flags().String("templates", "", "a directory of templates overriding the built-in templates with the same names")
+	cmd.Flags().String("boilerplate", "", "the path to a license boilerplate to prepend to generated Go files")
+	output.AddFlags(cmd.Flags())
 	return cmd
 }
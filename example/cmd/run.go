@@ -5,7 +5,9 @@
 package cmd
 
 import (
+	"github.com/atomix/codegen/example/templates"
 	"github.com/atomix/codegen/pkg/generator"
+	"github.com/atomix/codegen/pkg/generator/output"
 	"github.com/atomix/codegen/pkg/generator/proto"
 	"github.com/atomix/codegen/pkg/generator/template"
 	"github.com/spf13/cobra"
@@ -13,6 +15,12 @@ import (
 )
 
 func run(cmd *cobra.Command, args []string) error {
+	outputMode, err := output.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	output.SetMode(outputMode)
+
 	inputPath, err := cmd.Flags().GetString("input")
 	if err != nil {
 		return err
@@ -33,13 +41,33 @@ func run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	mode := proto.ProtocMode
+	inProcess, err := cmd.Flags().GetBool("in-process")
+	if err != nil {
+		return err
+	} else if inProcess {
+		mode = proto.InProcessMode
+	}
+
+	boilerplate, err := cmd.Flags().GetString("boilerplate")
+	if err != nil {
+		return err
+	}
+
+	overrideDir, err := cmd.Flags().GetString("templates")
+	if err != nil {
+		return err
+	}
+	loader := template.NewLoader(templates.FS, overrideDir).Scaffold("go.mod.tpl")
+
 	config := generator.Config{
 		Generator: "driver",
 		Config: template.Config{
+			Boilerplate: boilerplate,
 			Templates: []template.TemplateConfig{
 				{
 					Name: "go.mod",
-					Path: getTemplatePath("go.mod.tpl"),
+					Path: "go.mod.tpl",
 					Output: template.OutputConfig{
 						Path: filepath.Join(outputPath, "go.mod"),
 					},
@@ -47,6 +75,7 @@ func run(cmd *cobra.Command, args []string) error {
 			},
 		},
 		Proto: &proto.Config{
+			Mode: mode,
 			Input: proto.InputConfig{
 				Repo: proto.InputRepo{
 					URL: inputRepoURL,
@@ -59,16 +88,20 @@ func run(cmd *cobra.Command, args []string) error {
 			},
 			Templates: []proto.TemplateConfig{
 				{
-					Name: "primitive.go",
-					Path: getTemplatePath("primitive.go.tpl"),
+					Name: "atom.go",
+					Path: "atom.go.tpl",
 					Output: proto.TemplateOutputConfig{
-						PathTemplate: "primitives/{{ .Atom.Name | toSnake }}.go",
+						PathTemplate: "primitives/{{ .Service.Name | toSnake }}.go",
 					},
 				},
 			},
 		},
 	}
 
+	if err := loader.Load(config.Sources()...); err != nil {
+		return err
+	}
+
 	context := Context{
 		Foo: "bar",
 		Bar: "baz",
@@ -79,7 +112,7 @@ func run(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
-	return nil
+	return output.Check()
 }
 
 type Context struct {
@@ -87,7 +120,3 @@ type Context struct {
 	Bar string
 	Baz string
 }
-
-func getTemplatePath(name string) string {
-	return filepath.Join("/templates/", name)
-}
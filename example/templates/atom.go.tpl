@@ -1 +1,4 @@
-package {{ base .Atom.Name | lower }}
+package primitives
+
+// {{ .Service.Name }} is an example {{ .Service.Name }} primitive
+type {{ .Service.Name }} struct{}
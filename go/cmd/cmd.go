@@ -4,21 +4,27 @@
 
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"github.com/atomix/codegen/pkg/generator/output"
+	"github.com/atomix/codegen/pkg/version"
+	"github.com/spf13/cobra"
+)
 
 func GetCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "atomix-gen-go",
+		Use:     "go",
 		Short:   "Generates Go sources from Protobuf sources",
+		Version: version.String(),
 		Aliases: []string{"golang"},
 		Args:    cobra.NoArgs,
 		RunE:    run,
 	}
-	cmd.Flags().StringP("config", "c", "", "the path to the generator configuration")
 	cmd.Flags().StringSliceP("proto-path", "p", []string{"."}, "the relative path to the Protobuf API root")
 	cmd.Flags().StringSliceP("proto-files", "f", []string{"**/*.proto"}, "file name patterns by which to filter Protobuf sources")
+	cmd.Flags().StringSlice("proto-include", []string{}, "additional paths from which to resolve Protobuf imports")
 	cmd.Flags().StringP("go-path", "d", ".", "the relative path to the documentation root")
 	cmd.Flags().StringP("import-path", "i", "", "the base Go path for generated sources")
-	_ = cmd.MarkFlagFilename("config")
+	cmd.Flags().String("boilerplate", "", "the path to a license boilerplate to prepend to generated Go files")
+	output.AddFlags(cmd.Flags())
 	return cmd
 }
@@ -31,11 +31,13 @@ type Config struct {
 }
 
 type ProtoConfig struct {
-	Path  []string `yaml:"path,omitempty"`
-	Files []string `yaml:"files,omitempty"`
+	Path    []string `yaml:"path,omitempty"`
+	Files   []string `yaml:"files,omitempty"`
+	Include []string `yaml:"include,omitempty"`
 }
 
 type GoConfig struct {
-	Path       string `yaml:"path,omitempty"`
-	ImportPath string `yaml:"import_path,omitempty"`
+	Path        string `yaml:"path,omitempty"`
+	ImportPath  string `yaml:"import_path,omitempty"`
+	Boilerplate string `yaml:"boilerplate,omitempty"`
 }
@@ -7,8 +7,12 @@ package cmd
 import (
 	"fmt"
 	"github.com/atomix/codegen/pkg/exec"
+	"github.com/atomix/codegen/pkg/generator/output"
+	"github.com/atomix/codegen/pkg/generator/proto/include"
+	"github.com/atomix/codegen/pkg/generator/template"
 	"github.com/bmatcuk/doublestar/v4"
 	"io/fs"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strings"
@@ -30,10 +34,25 @@ func NewGenerator(config Config) *Generator {
 }
 
 type Generator struct {
-	Config Config
+	Config      Config
+	includeDir  string
+	boilerplate string
 }
 
 func (g *Generator) Generate() error {
+	includeDir, err := include.TempDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(includeDir)
+	g.includeDir = includeDir
+
+	boilerplate, err := template.ReadBoilerplate(g.Config.Go.Boilerplate)
+	if err != nil {
+		return err
+	}
+	g.boilerplate = strings.TrimSpace(boilerplate)
+
 	importMappings := make(map[string]string)
 	importMappings["google/protobuf/any.proto"] = "github.com/gogo/protobuf/types"
 	importMappings["google/protobuf/timestamp.proto"] = "github.com/gogo/protobuf/types"
@@ -61,14 +80,38 @@ func (g *Generator) Generate() error {
 func (g *Generator) gen(file string, spec Spec) error {
 	var path []string
 	path = append(path, g.Config.Proto.Path...)
-	path = append(path, filepath.Join(os.Getenv("GOPATH"), "src/github.com/gogo/protobuf"))
-
-	var args []string
-	args = append(args, "-I", strings.Join(path, ":"))
-	args = append(args, fmt.Sprintf("--gogofaster_out=%s", spec.String()))
-	args = append(args, file)
+	path = append(path, g.Config.Proto.Include...)
+	path = append(path, g.includeDir)
+
+	return output.GenerateDir(spec.OutputPath, output.AlwaysPolicy, func(outDir string) error {
+		spec.OutputPath = outDir
+		var args []string
+		args = append(args, "-I", strings.Join(path, ":"))
+		args = append(args, fmt.Sprintf("--gogofaster_out=%s", spec.String()))
+		args = append(args, file)
+		if err := exec.Run("protoc", args...); err != nil {
+			return err
+		}
+		return g.prependBoilerplate(outDir)
+	})
+}
 
-	return exec.Run("protoc", args...)
+// prependBoilerplate prepends the license boilerplate to the Go sources generated into dir, ahead of
+// the "Code generated ... DO NOT EDIT." line written by protoc-gen-gogo
+func (g *Generator) prependBoilerplate(dir string) error {
+	if g.boilerplate == "" {
+		return nil
+	}
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || filepath.Ext(path) != ".go" {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return ioutil.WriteFile(path, append([]byte(g.boilerplate+"\n\n"), content...), 0644)
+	})
 }
 
 func NewGo(parent *Generator, imports map[string]string) *GoGenerator {
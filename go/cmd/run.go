@@ -5,11 +5,17 @@
 package cmd
 
 import (
+	"github.com/atomix/codegen/pkg/generator/output"
 	"github.com/spf13/cobra"
-	"os"
 )
 
 func run(cmd *cobra.Command, args []string) error {
+	outputMode, err := output.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	output.SetMode(outputMode)
+
 	var config Config
 	configPath, err := cmd.Flags().GetString("config")
 	if err != nil {
@@ -35,11 +41,13 @@ func run(cmd *cobra.Command, args []string) error {
 	}
 	config.Proto.Files = protoFiles
 
-	goPath, err := cmd.Flags().GetString("go-path")
+	protoIncludes, err := cmd.Flags().GetStringSlice("proto-include")
 	if err != nil {
 		return err
 	}
-	err = os.MkdirAll(goPath, 0755)
+	config.Proto.Include = append(config.Proto.Include, protoIncludes...)
+
+	goPath, err := cmd.Flags().GetString("go-path")
 	if err != nil {
 		return err
 	}
@@ -50,5 +58,16 @@ func run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 	config.Go.ImportPath = importPath
-	return Generate(config)
+
+	boilerplate, err := cmd.Flags().GetString("boilerplate")
+	if err != nil {
+		return err
+	}
+	if boilerplate != "" {
+		config.Go.Boilerplate = boilerplate
+	}
+	if err := Generate(config); err != nil {
+		return err
+	}
+	return output.Check()
 }
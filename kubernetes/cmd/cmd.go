@@ -5,14 +5,18 @@
 package cmd
 
 import (
+	"github.com/atomix/codegen/pkg/generator/output"
+	"github.com/atomix/codegen/pkg/version"
 	"github.com/spf13/cobra"
 )
 
 func GetCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:  "atomix-gen-kubernetes",
-		Args: cobra.NoArgs,
-		RunE: run,
+		Use:     "kubernetes",
+		Short:   "Generates Kubernetes deepcopy functions and API clients",
+		Version: version.String(),
+		Args:    cobra.NoArgs,
+		RunE:    run,
 	}
 	cmd.Flags().StringP("input-path", "p", ".", "the relative path to the API root")
 	cmd.Flags().StringP("output-path", "o", "", "the relative path to the output directory")
@@ -20,6 +24,7 @@ func GetCommand() *cobra.Command {
 	cmd.Flags().Bool("deepcopy", false, "generate deepcopy files")
 	cmd.Flags().Bool("client", false, "generate API clients")
 	cmd.Flags().String("boilerplate", "", "the path to a boilerplate file")
+	output.AddFlags(cmd.Flags())
 	_ = cmd.MarkFlagRequired("input-path")
 	_ = cmd.MarkFlagRequired("input-path")
 	_ = cmd.MarkFlagRequired("group-version")
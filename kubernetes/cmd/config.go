@@ -0,0 +1,22 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+type Config struct {
+	Input        InputConfig  `yaml:"input,omitempty"`
+	Output       OutputConfig `yaml:"output,omitempty"`
+	GroupVersion string       `yaml:"groupVersion,omitempty"`
+	Deepcopy     bool         `yaml:"deepcopy,omitempty"`
+	Client       bool         `yaml:"client,omitempty"`
+	Boilerplate  string       `yaml:"boilerplate,omitempty"`
+}
+
+type InputConfig struct {
+	Path string `yaml:"path,omitempty"`
+}
+
+type OutputConfig struct {
+	Path string `yaml:"path,omitempty"`
+}
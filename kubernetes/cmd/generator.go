@@ -0,0 +1,73 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package cmd
+
+import (
+	"github.com/atomix/codegen/pkg/exec"
+	"github.com/atomix/codegen/pkg/generator/output"
+	"go/build"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func Generate(config Config) error {
+	return NewGenerator(config).Generate()
+}
+
+func NewGenerator(config Config) *Generator {
+	if config.Input.Path == "" {
+		config.Input.Path = "."
+	}
+	if config.Output.Path == "" {
+		config.Output.Path = config.Input.Path
+	}
+	return &Generator{
+		Config: config,
+	}
+}
+
+type Generator struct {
+	Config Config
+}
+
+func (g *Generator) Generate() error {
+	tmpDir, err := ioutil.TempDir("", "code-generator")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	err = exec.Run("git", "clone", "https://github.com/kubernetes/code-generator.git", tmpDir)
+	if err != nil {
+		return err
+	}
+	err = exec.RunIn(tmpDir, "git", "checkout", "release-1.24")
+	if err != nil {
+		return err
+	}
+
+	var generators []string
+	if g.Config.Deepcopy {
+		generators = append(generators, "deepcopy")
+	}
+	if g.Config.Client {
+		generators = append(generators, "client")
+	}
+
+	// The generated files are written under the output base in place of the GOPATH
+	return output.GenerateDir(filepath.Join(build.Default.GOPATH, "src"), output.AlwaysPolicy, func(outDir string) error {
+		var args []string
+		args = append(args, filepath.Join(tmpDir, "generate-groups.sh"))
+		args = append(args, strings.Join(generators, ","))
+		args = append(args, g.Config.Input.Path, g.Config.Output.Path, g.Config.GroupVersion)
+		args = append(args, "--output-base", outDir)
+		if g.Config.Boilerplate != "" {
+			args = append(args, "--go-header-file", g.Config.Boilerplate)
+		}
+		return exec.Run("bash", args...)
+	})
+}
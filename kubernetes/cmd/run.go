@@ -5,15 +5,17 @@
 package cmd
 
 import (
-	"github.com/atomix/codegen/pkg/exec"
+	"github.com/atomix/codegen/pkg/generator/output"
 	"github.com/spf13/cobra"
-	"io/ioutil"
-	"os"
-	"path/filepath"
-	"strings"
 )
 
 func run(cmd *cobra.Command, _ []string) error {
+	outputMode, err := output.ModeFromFlags(cmd.Flags())
+	if err != nil {
+		return err
+	}
+	output.SetMode(outputMode)
+
 	inputPath, err := cmd.Flags().GetString("input-path")
 	if err != nil {
 		return err
@@ -22,9 +24,6 @@ func run(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
-	if outputPath == "" {
-		outputPath = inputPath
-	}
 	groupVersion, err := cmd.Flags().GetString("group-version")
 	if err != nil {
 		return err
@@ -42,39 +41,20 @@ func run(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	tmpDir, err := ioutil.TempDir("", "code-generator")
-	if err != nil {
-		return err
-	}
-	defer os.RemoveAll(tmpDir)
-
-	err = exec.Run("git", "clone", "https://github.com/kubernetes/code-generator.git", tmpDir)
-	if err != nil {
-		return err
-	}
-	err = exec.RunIn(tmpDir, "git", "checkout", "release-1.24")
-	if err != nil {
-		return err
-	}
-
-	var generators []string
-	if deepcopy {
-		generators = append(generators, "deepcopy")
-	}
-	if client {
-		generators = append(generators, "client")
-	}
-
-	var args []string
-	args = append(args, filepath.Join(tmpDir, "generate-groups.sh"))
-	args = append(args, strings.Join(generators, ","))
-	args = append(args, inputPath, outputPath, groupVersion)
-	if boilerplate != "" {
-		args = append(args, "--go-header-file", boilerplate)
-	}
-	err = exec.Run("bash", args...)
-	if err != nil {
-		return err
-	}
-	return nil
+	err = Generate(Config{
+		Input: InputConfig{
+			Path: inputPath,
+		},
+		Output: OutputConfig{
+			Path: outputPath,
+		},
+		GroupVersion: groupVersion,
+		Deepcopy:     deepcopy,
+		Client:       client,
+		Boilerplate:  boilerplate,
+	})
+	if err != nil {
+		return err
+	}
+	return output.Check()
 }
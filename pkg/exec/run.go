@@ -10,6 +10,13 @@ import (
 	"strings"
 )
 
+var verbosity = 1
+
+// SetVerbosity sets the output verbosity; commands are echoed before they're run at verbosity 1 and above
+func SetVerbosity(level int) {
+	verbosity = level
+}
+
 func Run(command string, args ...string) error {
 	wd, err := os.Getwd()
 	if err != nil {
@@ -24,6 +31,8 @@ func RunIn(dir string, command string, args ...string) error {
 	cmd.Env = os.Environ()
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
-	println(strings.Join(cmd.Args, " "))
+	if verbosity > 0 {
+		println(strings.Join(cmd.Args, " "))
+	}
 	return cmd.Run()
 }
@@ -14,3 +14,17 @@ type Config struct {
 	template.Config `yaml:",inline"`
 	Proto           *proto.Config `yaml:"proto,omitempty"`
 }
+
+// Sources returns the templates rendered by the generator, for their content to be read by a template.Loader
+func (c *Config) Sources() []template.Source {
+	var sources []template.Source
+	for i := range c.Templates {
+		sources = append(sources, &c.Templates[i])
+	}
+	if c.Proto != nil {
+		for i := range c.Proto.Templates {
+			sources = append(sources, &c.Proto.Templates[i])
+		}
+	}
+	return sources
+}
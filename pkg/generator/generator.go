@@ -28,7 +28,11 @@ func (g *Generator) Generate(values interface{}) error {
 		return err
 	}
 	if g.Config.Proto != nil {
-		if err := proto.Generate(*g.Config.Proto, values); err != nil {
+		protoConfig := *g.Config.Proto
+		if protoConfig.Boilerplate == "" {
+			protoConfig.Boilerplate = g.Config.Boilerplate
+		}
+		if err := proto.Generate(protoConfig, values); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,51 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package output
+
+import (
+	"errors"
+	"github.com/spf13/pflag"
+)
+
+const (
+	dryRunFlag = "dry-run"
+	diffFlag   = "diff"
+	checkFlag  = "check"
+)
+
+// AddFlags adds the --dry-run, --diff and --check output mode flags to the given flag set
+func AddFlags(flags *pflag.FlagSet) {
+	flags.Bool(dryRunFlag, false, "list the files that would be created or changed without writing them")
+	flags.Bool(diffFlag, false, "print diffs of the files that would be created or changed without writing them")
+	flags.Bool(checkFlag, false, "fail if any generated files are missing or out of date without writing them")
+}
+
+// ModeFromFlags returns the mode selected by the flags added with AddFlags
+func ModeFromFlags(flags *pflag.FlagSet) (Mode, error) {
+	dryRun, err := flags.GetBool(dryRunFlag)
+	if err != nil {
+		return "", err
+	}
+	diff, err := flags.GetBool(diffFlag)
+	if err != nil {
+		return "", err
+	}
+	check, err := flags.GetBool(checkFlag)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case dryRun && diff, dryRun && check, diff && check:
+		return "", errors.New("only one of --dry-run, --diff and --check may be set")
+	case dryRun:
+		return DryRunMode, nil
+	case diff:
+		return DiffMode, nil
+	case check:
+		return CheckMode, nil
+	default:
+		return WriteMode, nil
+	}
+}
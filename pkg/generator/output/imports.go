@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package output
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"golang.org/x/tools/go/ast/astutil"
+	"path"
+	"regexp"
+	"strconv"
+)
+
+var versionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// PruneImports removes the imports the Go source at the given path does not use. Unlike goimports, no
+// packages are loaded, so imports whose package name is not known from the import spec are kept, e.g. blank
+// and dot imports and unnamed imports of paths ending in a major version. Imports on lines for which keep
+// returns true are kept as well. Sources that cannot be parsed are returned unchanged.
+func PruneImports(filePath string, src []byte, keep func(line int) bool) []byte {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, src, parser.ParseComments)
+	if err != nil {
+		return src
+	}
+
+	var unused []*ast.ImportSpec
+	for _, spec := range file.Imports {
+		if keep != nil && keep(fset.Position(spec.Pos()).Line) {
+			continue
+		}
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || !isPackageNameKnown(spec, importPath) {
+			continue
+		}
+		if !astutil.UsesImport(file, importPath) {
+			unused = append(unused, spec)
+		}
+	}
+	if len(unused) == 0 {
+		return src
+	}
+
+	for _, spec := range unused {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		var name string
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		astutil.DeleteNamedImport(fset, file, name, importPath)
+	}
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return src
+	}
+	return buf.Bytes()
+}
+
+// isPackageNameKnown returns whether the name by which the source refers to the imported package is known
+// without loading the package, i.e. the import is named or the path ends in the conventional package name
+func isPackageNameKnown(spec *ast.ImportSpec, importPath string) bool {
+	if spec.Name != nil {
+		return spec.Name.Name != "_" && spec.Name.Name != "."
+	}
+	name := path.Base(importPath)
+	return token.IsIdentifier(name) && !versionSuffix.MatchString(name)
+}
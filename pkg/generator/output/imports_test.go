@@ -0,0 +1,81 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package output
+
+import (
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+)
+
+func TestPruneImports(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		keep     func(line int) bool
+		expected []string
+	}{
+		{
+			name:     "unused import",
+			src:      "package driver\n\nimport (\n\t\"fmt\"\n\t\"strings\"\n)\n\nvar _ = strings.ToUpper\n",
+			expected: []string{"strings"},
+		},
+		{
+			name:     "unused named import",
+			src:      "package driver\n\nimport (\n\tcounterv1 \"github.com/example/api/counter/v1\"\n\t\"strings\"\n)\n\nvar _ = strings.ToUpper\n",
+			expected: []string{"strings"},
+		},
+		{
+			name:     "unknown package name",
+			src:      "package driver\n\nimport (\n\t\"github.com/example/api/counter/v1\"\n\t_ \"embed\"\n)\n",
+			expected: []string{"github.com/example/api/counter/v1", "embed"},
+		},
+		{
+			name: "kept line",
+			src:  "package driver\n\nimport (\n\t\"fmt\"\n\t\"strings\"\n)\n",
+			keep: func(line int) bool {
+				return line == 4
+			},
+			expected: []string{"fmt"},
+		},
+		{
+			name:     "syntax error",
+			src:      "package driver\n\nimport \"fmt\"\n\nfunc {\n",
+			expected: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			out := PruneImports("driver.go", []byte(test.src), test.keep)
+			if test.expected == nil {
+				if string(out) != test.src {
+					t.Fatalf("expected source to be unchanged, got:\n%s", out)
+				}
+				return
+			}
+			file, err := parser.ParseFile(token.NewFileSet(), "driver.go", out, parser.ImportsOnly)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var actual []string
+			for _, spec := range file.Imports {
+				path, err := strconv.Unquote(spec.Path.Value)
+				if err != nil {
+					t.Fatal(err)
+				}
+				actual = append(actual, path)
+			}
+			if len(actual) != len(test.expected) {
+				t.Fatalf("expected imports %v, got %v", test.expected, actual)
+			}
+			for i, path := range test.expected {
+				if actual[i] != path {
+					t.Errorf("expected imports %v, got %v", test.expected, actual)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/pmezard/go-difflib/difflib"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Mode determines how generated files are written to the workspace
+type Mode string
+
+const (
+	// WriteMode writes generated files to the workspace
+	WriteMode Mode = "write"
+	// DryRunMode lists the files that would be created or changed without writing them
+	DryRunMode Mode = "dry-run"
+	// DiffMode prints unified diffs of the files that would be created or changed without writing them
+	DiffMode Mode = "diff"
+	// CheckMode records the files that are missing or out of date without writing them
+	CheckMode Mode = "check"
+)
+
+var std = NewWriter(WriteMode, os.Stdout)
+
+// SetMode sets the mode of the default writer
+func SetMode(mode Mode) {
+	std.Mode = mode
+}
+
+// Write writes a generated file according to the policy with the default writer
+func Write(path string, content []byte, policy Policy) error {
+	return std.Write(path, content, policy)
+}
+
+// WriteDir writes the files generated into dir to the same relative paths under root according to the policy
+// with the default writer
+func WriteDir(dir string, root string, policy Policy) error {
+	return std.WriteDir(dir, root, policy)
+}
+
+// GenerateDir runs gen to generate files into a temporary directory and writes them to the same relative paths
+// under root according to the policy with the default writer
+func GenerateDir(root string, policy Policy, gen func(dir string) error) error {
+	return std.GenerateDir(root, policy, gen)
+}
+
+// Check returns an error listing the files the default writer found to be out of date in check mode
+func Check() error {
+	return std.Check()
+}
+
+// NewWriter creates a new writer that reports files in dry-run and diff modes to out
+func NewWriter(mode Mode, out io.Writer) *Writer {
+	return &Writer{
+		Mode: mode,
+		Out:  out,
+		Err:  os.Stderr,
+	}
+}
+
+// Writer writes generated files to the workspace according to its mode
+type Writer struct {
+	Mode  Mode
+	Out   io.Writer
+	Err   io.Writer
+	stale []string
+}
+
+// Write writes the generated content to the given path. The policy determines whether and how an existing
+// file is replaced, and files whose content is unchanged are not written.
+func (w *Writer) Write(path string, content []byte, policy Policy) error {
+	if err := policy.validate(content); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	existing, err := ioutil.ReadFile(path)
+	exists := err == nil
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if exists {
+		var write bool
+		content, write, err = w.apply(policy, path, existing, content)
+		if err != nil || !write {
+			return err
+		}
+	}
+	if exists && bytes.Equal(existing, content) {
+		return nil
+	}
+
+	switch w.Mode {
+	case WriteMode, "":
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		return ioutil.WriteFile(path, content, 0644)
+	case DryRunMode:
+		if exists {
+			_, err = fmt.Fprintf(w.Out, "update %s\n", path)
+		} else {
+			_, err = fmt.Fprintf(w.Out, "create %s\n", path)
+		}
+		return err
+	case DiffMode:
+		fromFile := path
+		if !exists {
+			fromFile = os.DevNull
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(string(existing)),
+			B:        splitLines(string(content)),
+			FromFile: fromFile,
+			ToFile:   path,
+			Context:  3,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w.Out, diff)
+		return err
+	case CheckMode:
+		w.stale = append(w.stale, path)
+		return nil
+	default:
+		return fmt.Errorf("unknown output mode '%s'", w.Mode)
+	}
+}
+
+// WriteDir writes the files generated into dir, e.g. by protoc, to the same relative paths under root
+func (w *Writer) WriteDir(dir string, root string, policy Policy) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return w.Write(filepath.Join(root, name), content, policy)
+	})
+}
+
+// GenerateDir runs gen to generate files into a temporary directory, e.g. with protoc, and writes them to the
+// same relative paths under root. Generating into a temporary directory lets the output be compared with the
+// workspace before it's written.
+func (w *Writer) GenerateDir(root string, policy Policy, gen func(dir string) error) error {
+	dir, err := ioutil.TempDir("", "output")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	if err := gen(dir); err != nil {
+		return err
+	}
+	return w.WriteDir(dir, root, policy)
+}
+
+// Check returns an error listing the files found to be missing or out of date in check mode
+func (w *Writer) Check() error {
+	if len(w.stale) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d generated file(s) out of date:\n  %s", len(w.stale), strings.Join(w.stale, "\n  "))
+}
+
+// splitLines splits the text into lines for diffing, without the empty line difflib.SplitLines adds after a final newline
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		return lines[:len(lines)-1]
+	}
+	lines[len(lines)-1] += "\n"
+	return lines
+}
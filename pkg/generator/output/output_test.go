@@ -0,0 +1,375 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package output
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	generatedFile = "// Code generated by atomix-codegen v1.0.0. DO NOT EDIT.\n\npackage driver\n\nfunc Get() {\n\t// atomix-codegen:begin-user-code Get\n\tpanic(\"get\")\n\t// atomix-codegen:end-user-code Get\n}\n"
+	customFile    = "package driver\n\nfunc Get() {\n\t// atomix-codegen:begin-user-code Get\n\tpanic(\"get\")\n\t// atomix-codegen:end-user-code Get\n}\n"
+	generated     = "// Code generated by atomix-codegen v1.1.0. DO NOT EDIT.\n\npackage driver\n\nfunc Get() {\n\t// atomix-codegen:begin-user-code Get\n\t// atomix-codegen:end-user-code Get\n}\n\nfunc Put() {}\n"
+)
+
+func TestWritePolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   Policy
+		existing string
+		exists   bool
+		expected string
+	}{
+		{
+			name:     "merge new file",
+			policy:   MergePolicy,
+			expected: generated,
+		},
+		{
+			name:     "merge custom file",
+			policy:   MergePolicy,
+			existing: customFile,
+			exists:   true,
+			expected: "// Code generated by atomix-codegen v1.1.0. DO NOT EDIT.\n\npackage driver\n\nfunc Get() {\n\t// atomix-codegen:begin-user-code Get\n\tpanic(\"get\")\n\t// atomix-codegen:end-user-code Get\n}\n\nfunc Put() {}\n",
+		},
+		{
+			name:     "merge generated file",
+			policy:   MergePolicy,
+			existing: generatedFile,
+			exists:   true,
+			expected: "// Code generated by atomix-codegen v1.1.0. DO NOT EDIT.\n\npackage driver\n\nfunc Get() {\n\t// atomix-codegen:begin-user-code Get\n\tpanic(\"get\")\n\t// atomix-codegen:end-user-code Get\n}\n\nfunc Put() {}\n",
+		},
+		{
+			name:     "default policy merges",
+			existing: generatedFile,
+			exists:   true,
+			expected: "// Code generated by atomix-codegen v1.1.0. DO NOT EDIT.\n\npackage driver\n\nfunc Get() {\n\t// atomix-codegen:begin-user-code Get\n\tpanic(\"get\")\n\t// atomix-codegen:end-user-code Get\n}\n\nfunc Put() {}\n",
+		},
+		{
+			name:     "always new file",
+			policy:   AlwaysPolicy,
+			expected: generated,
+		},
+		{
+			name:     "always custom file",
+			policy:   AlwaysPolicy,
+			existing: customFile,
+			exists:   true,
+			expected: generated,
+		},
+		{
+			name:     "always generated file",
+			policy:   AlwaysPolicy,
+			existing: generatedFile,
+			exists:   true,
+			expected: generated,
+		},
+		{
+			name:     "create-only new file",
+			policy:   CreateOnlyPolicy,
+			expected: generated,
+		},
+		{
+			name:     "create-only custom file",
+			policy:   CreateOnlyPolicy,
+			existing: customFile,
+			exists:   true,
+			expected: customFile,
+		},
+		{
+			name:     "create-only generated file",
+			policy:   CreateOnlyPolicy,
+			existing: generatedFile,
+			exists:   true,
+			expected: generatedFile,
+		},
+		{
+			name:     "if-generated new file",
+			policy:   IfGeneratedPolicy,
+			expected: generated,
+		},
+		{
+			name:     "if-generated custom file",
+			policy:   IfGeneratedPolicy,
+			existing: customFile,
+			exists:   true,
+			expected: customFile,
+		},
+		{
+			name:     "if-generated generated file",
+			policy:   IfGeneratedPolicy,
+			existing: generatedFile,
+			exists:   true,
+			expected: "// Code generated by atomix-codegen v1.1.0. DO NOT EDIT.\n\npackage driver\n\nfunc Get() {\n\t// atomix-codegen:begin-user-code Get\n\tpanic(\"get\")\n\t// atomix-codegen:end-user-code Get\n}\n\nfunc Put() {}\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "driver", "driver.go")
+			if test.exists {
+				writeFile(t, path, test.existing)
+			}
+			var out bytes.Buffer
+			writer := NewWriter(WriteMode, &out)
+			if err := writer.Write(path, []byte(generated), test.policy); err != nil {
+				t.Fatal(err)
+			}
+			if actual := readFile(t, path); actual != test.expected {
+				t.Errorf("expected file content:\n%s\ngot:\n%s", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIfGeneratedPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		existing string
+		content  string
+		expected string
+		err      bool
+	}{
+		{
+			name:     "generated Makefile",
+			path:     "Makefile",
+			existing: "# Code generated by atomix-codegen v1.0.0. DO NOT EDIT.\n\nbuild:\n\tgo build ./cmd/driver\n",
+			content:  "# Code generated by atomix-codegen v1.1.0. DO NOT EDIT.\n\nbuild:\n\tgo build ./...\n",
+			expected: "# Code generated by atomix-codegen v1.1.0. DO NOT EDIT.\n\nbuild:\n\tgo build ./...\n",
+		},
+		{
+			name:     "custom Makefile",
+			path:     "Makefile",
+			existing: "build:\n\tgo build ./cmd/driver\n",
+			content:  "# Code generated by atomix-codegen v1.1.0. DO NOT EDIT.\n\nbuild:\n\tgo build ./...\n",
+			expected: "build:\n\tgo build ./cmd/driver\n",
+		},
+		{
+			name:     "generated Markdown",
+			path:     "README.md",
+			existing: "<!-- Code generated by atomix-codegen v1.0.0. DO NOT EDIT. -->\n# Driver\n",
+			content:  "<!-- Code generated by atomix-codegen v1.1.0. DO NOT EDIT. -->\n# Counter driver\n",
+			expected: "<!-- Code generated by atomix-codegen v1.1.0. DO NOT EDIT. -->\n# Counter driver\n",
+		},
+		{
+			name:     "marker outside a comment",
+			path:     "Makefile",
+			existing: "build:\n\techo \"Code generated by atomix-codegen. DO NOT EDIT.\"\n",
+			content:  "# Code generated by atomix-codegen v1.1.0. DO NOT EDIT.\n\nbuild:\n\tgo build ./...\n",
+			expected: "build:\n\techo \"Code generated by atomix-codegen. DO NOT EDIT.\"\n",
+		},
+		{
+			name:    "no marker in generated content",
+			path:    "Makefile",
+			content: "build:\n\tgo build ./...\n",
+			err:     true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), test.path)
+			if test.existing != "" {
+				writeFile(t, path, test.existing)
+			}
+			writer := NewWriter(WriteMode, ioutil.Discard)
+			err := writer.Write(path, []byte(test.content), IfGeneratedPolicy)
+			if test.err {
+				if err == nil {
+					t.Fatal("expected an error for generated content without a marker")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual := readFile(t, path); actual != test.expected {
+				t.Errorf("expected file content:\n%s\ngot:\n%s", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestWriteUnknownPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "driver.go")
+	writer := NewWriter(WriteMode, ioutil.Discard)
+	if err := writer.Write(path, []byte(generated), "sometimes"); err == nil {
+		t.Fatal("expected an error for an unknown policy")
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected %s not to be written", path)
+	}
+}
+
+func TestWriteDroppedRegion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "driver.go")
+	writeFile(t, path, customFile)
+	var errOut bytes.Buffer
+	writer := NewWriter(WriteMode, ioutil.Discard)
+	writer.Err = &errOut
+	if err := writer.Write(path, []byte("package driver\n"), MergePolicy); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(errOut.String(), "user code region Get no longer exists") {
+		t.Errorf("expected a warning for the dropped region, got %q", errOut.String())
+	}
+}
+
+func TestWriteModes(t *testing.T) {
+	tests := []struct {
+		name     string
+		mode     Mode
+		existing string
+		exists   bool
+		out      string
+		stale    bool
+	}{
+		{
+			name: "dry-run new file",
+			mode: DryRunMode,
+			out:  "create {path}\n",
+		},
+		{
+			name:     "dry-run changed file",
+			mode:     DryRunMode,
+			existing: "package driver\n",
+			exists:   true,
+			out:      "update {path}\n",
+		},
+		{
+			name:     "dry-run unchanged file",
+			mode:     DryRunMode,
+			existing: generated,
+			exists:   true,
+		},
+		{
+			name: "diff new file",
+			mode: DiffMode,
+			out:  "--- " + os.DevNull + "\n+++ {path}\n@@ -0,0 +1,10 @@\n+// Code generated by atomix-codegen v1.1.0. DO NOT EDIT.\n+\n+package driver\n+\n+func Get() {\n+\t// atomix-codegen:begin-user-code Get\n+\t// atomix-codegen:end-user-code Get\n+}\n+\n+func Put() {}\n",
+		},
+		{
+			name:     "diff changed file",
+			mode:     DiffMode,
+			existing: strings.Replace(generated, "Put", "Delete", 1),
+			exists:   true,
+			out:      "--- {path}\n+++ {path}\n@@ -7,4 +7,4 @@\n \t// atomix-codegen:end-user-code Get\n }\n \n-func Delete() {}\n+func Put() {}\n",
+		},
+		{
+			name:     "diff unchanged file",
+			mode:     DiffMode,
+			existing: generated,
+			exists:   true,
+		},
+		{
+			name:  "check new file",
+			mode:  CheckMode,
+			stale: true,
+		},
+		{
+			name:     "check changed file",
+			mode:     CheckMode,
+			existing: "package driver\n",
+			exists:   true,
+			stale:    true,
+		},
+		{
+			name:     "check unchanged file",
+			mode:     CheckMode,
+			existing: generated,
+			exists:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "driver.go")
+			if test.exists {
+				writeFile(t, path, test.existing)
+			}
+			var out bytes.Buffer
+			writer := NewWriter(test.mode, &out)
+			if err := writer.Write(path, []byte(generated), AlwaysPolicy); err != nil {
+				t.Fatal(err)
+			}
+
+			if expected := strings.ReplaceAll(test.out, "{path}", path); out.String() != expected {
+				t.Errorf("expected output:\n%s\ngot:\n%s", expected, out.String())
+			}
+			if test.exists {
+				if actual := readFile(t, path); actual != test.existing {
+					t.Errorf("expected %s not to be changed, got:\n%s", path, actual)
+				}
+			} else if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Errorf("expected %s not to be created", path)
+			}
+
+			err := writer.Check()
+			if test.stale && (err == nil || !strings.Contains(err.Error(), path)) {
+				t.Errorf("expected check to fail listing %s, got %v", path, err)
+			} else if !test.stale && err != nil {
+				t.Errorf("expected check to succeed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestGenerateDir(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "api", "v1", "map.pb.go"), "package v1\n")
+
+	var genDir string
+	writer := NewWriter(DryRunMode, ioutil.Discard)
+	err := writer.GenerateDir(root, AlwaysPolicy, func(dir string) error {
+		genDir = dir
+		writeFile(t, filepath.Join(dir, "api", "v1", "map.pb.go"), "package v1\n\ntype Map struct{}\n")
+		writeFile(t, filepath.Join(dir, "api", "v1", "map.md"), "# Map\n")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(genDir); !os.IsNotExist(err) {
+		t.Errorf("expected temporary directory %s to be removed", genDir)
+	}
+
+	writer.Mode = CheckMode
+	err = writer.GenerateDir(root, AlwaysPolicy, func(dir string) error {
+		writeFile(t, filepath.Join(dir, "api", "v1", "map.pb.go"), "package v1\n\ntype Map struct{}\n")
+		writeFile(t, filepath.Join(dir, "api", "v1", "map.md"), "# Map\n")
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{filepath.Join(root, "api", "v1", "map.md"), filepath.Join(root, "api", "v1", "map.pb.go")}
+	if strings.Join(writer.stale, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected stale files %v, got %v", expected, writer.stale)
+	}
+	if actual := readFile(t, filepath.Join(root, "api", "v1", "map.pb.go")); actual != "package v1\n" {
+		t.Errorf("expected the workspace not to be changed, got:\n%s", actual)
+	}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(bytes)
+}
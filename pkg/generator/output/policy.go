@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package output
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Policy determines whether a generated file replaces an existing file
+type Policy string
+
+const (
+	// MergePolicy overwrites existing files, preserving their user code regions. This is the default policy.
+	MergePolicy Policy = "merge"
+	// AlwaysPolicy overwrites existing files, discarding any changes to them
+	AlwaysPolicy Policy = "always"
+	// CreateOnlyPolicy creates files that do not exist and never changes existing files
+	CreateOnlyPolicy Policy = "create-only"
+	// IfGeneratedPolicy overwrites existing files, preserving their user code regions, only while they still carry
+	// a generated code marker, so files from which the developer has removed the marker are left alone. Only Go
+	// sources get the marker from the generator; templates for other files must write it themselves, e.g.
+	//
+	//	# Code generated by atomix-codegen. DO NOT EDIT.
+	IfGeneratedPolicy Policy = "if-generated"
+)
+
+// generatedMarker matches the comment marking a file as generated (see https://golang.org/s/generatedcode).
+// The marker may use any comment syntax, so the same marker works in Go sources, Makefiles and YAML files.
+var generatedMarker = regexp.MustCompile(`(?m)^\W*Code generated .* DO NOT EDIT\.`)
+
+// validate returns an error if the policy is unknown or cannot be applied to the generated content
+func (p Policy) validate(content []byte) error {
+	switch p {
+	case MergePolicy, AlwaysPolicy, CreateOnlyPolicy, "":
+		return nil
+	case IfGeneratedPolicy:
+		// Without the marker the file would never be updated once it's created
+		if !generatedMarker.Match(content) {
+			return fmt.Errorf("write policy '%s' requires a \"Code generated ... DO NOT EDIT.\" comment in the generated content", p)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown write policy '%s'", p)
+	}
+}
+
+// apply applies the policy to the generated content for the existing file, returning the content to write
+// and whether the file should be written at all
+func (w *Writer) apply(policy Policy, path string, existing []byte, content []byte) ([]byte, bool, error) {
+	switch policy {
+	case AlwaysPolicy:
+		return content, true, nil
+	case CreateOnlyPolicy:
+		return nil, false, nil
+	case IfGeneratedPolicy:
+		if !generatedMarker.Match(existing) {
+			return nil, false, nil
+		}
+	}
+
+	merged, dropped, err := mergeUserCode(path, existing, content)
+	if err != nil {
+		return nil, false, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, id := range dropped {
+		fmt.Fprintf(w.Err, "warning: %s: user code region %s no longer exists in the generated output; its content was discarded\n", path, id)
+	}
+	return merged, true, nil
+}
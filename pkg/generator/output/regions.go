@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package output
+
+import (
+	"fmt"
+	"go/format"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// userCodeMarker matches the comments that delimit a user code region, e.g.
+//
+//	// atomix-codegen:begin-user-code Connect
+//	// atomix-codegen:end-user-code Connect
+//
+// The markers may use any comment syntax, so the same markers work in Go sources, Makefiles and YAML files.
+var userCodeMarker = regexp.MustCompile(`atomix-codegen:(begin|end)-user-code\s+(\S+)`)
+
+// region is a user code region delimited by the begin and end marker lines
+type region struct {
+	id    string
+	begin int
+	end   int
+}
+
+// parseRegions finds the user code regions in the given lines
+func parseRegions(lines []string) (map[string]region, []string, error) {
+	regions := make(map[string]region)
+	var ids []string
+	var current *region
+	for i, line := range lines {
+		match := userCodeMarker.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		kind, id := match[1], match[2]
+		switch kind {
+		case "begin":
+			if current != nil {
+				return nil, nil, fmt.Errorf("line %d: user code region %s begins inside region %s", i+1, id, current.id)
+			}
+			if _, ok := regions[id]; ok {
+				return nil, nil, fmt.Errorf("line %d: duplicate user code region %s", i+1, id)
+			}
+			current = &region{id: id, begin: i}
+		case "end":
+			if current == nil || current.id != id {
+				return nil, nil, fmt.Errorf("line %d: user code region %s ends but was never begun", i+1, id)
+			}
+			current.end = i
+			regions[id] = *current
+			ids = append(ids, id)
+			current = nil
+		}
+	}
+	if current != nil {
+		return nil, nil, fmt.Errorf("line %d: user code region %s is never ended", current.begin+1, current.id)
+	}
+	return regions, ids, nil
+}
+
+// mergeUserCode splices the content of the user code regions in the existing file into the matching regions
+// of the generated content. The IDs of regions in the existing file that no longer exist in the generated
+// content are returned so their loss can be reported. Go sources are reformatted after merging, and generated
+// imports the user code no longer needs are removed. Imports inside user code regions are left to the user.
+func mergeUserCode(path string, existing []byte, content []byte) ([]byte, []string, error) {
+	existingLines := strings.SplitAfter(string(existing), "\n")
+	existingRegions, existingIDs, err := parseRegions(existingLines)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(existingRegions) == 0 {
+		return content, nil, nil
+	}
+
+	contentLines := strings.SplitAfter(string(content), "\n")
+	contentRegions, _, err := parseRegions(contentLines)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generated output: %w", err)
+	}
+
+	var dropped []string
+	for _, id := range existingIDs {
+		if _, ok := contentRegions[id]; !ok {
+			dropped = append(dropped, id)
+		}
+	}
+
+	var merged strings.Builder
+	for i := 0; i < len(contentLines); i++ {
+		merged.WriteString(contentLines[i])
+		match := userCodeMarker.FindStringSubmatch(contentLines[i])
+		if match == nil || match[1] != "begin" {
+			continue
+		}
+		existingRegion, ok := existingRegions[match[2]]
+		if !ok {
+			continue
+		}
+		for _, line := range existingLines[existingRegion.begin+1 : existingRegion.end] {
+			merged.WriteString(line)
+		}
+		i = contentRegions[match[2]].end - 1
+	}
+
+	if filepath.Ext(path) == ".go" {
+		mergedLines := strings.SplitAfter(merged.String(), "\n")
+		mergedRegions, _, err := parseRegions(mergedLines)
+		if err != nil {
+			return nil, nil, err
+		}
+		inRegion := func(line int) bool {
+			for _, region := range mergedRegions {
+				if line > region.begin+1 && line < region.end+1 {
+					return true
+				}
+			}
+			return false
+		}
+		// User code that does not parse is left for the compiler to report
+		pruned := PruneImports(path, []byte(merged.String()), inRegion)
+		if formatted, err := format.Source(pruned); err == nil {
+			return formatted, dropped, nil
+		}
+	}
+	return []byte(merged.String()), dropped, nil
+}
@@ -0,0 +1,180 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package output
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRegions(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected []region
+		err      string
+	}{
+		{
+			name: "no regions",
+			src:  "all:\n\tgo build ./...\n",
+		},
+		{
+			name: "regions",
+			src:  "# atomix-codegen:begin-user-code targets\nbuild:\n# atomix-codegen:end-user-code targets\n// atomix-codegen:begin-user-code Get\n// atomix-codegen:end-user-code Get\n",
+			expected: []region{
+				{id: "targets", begin: 0, end: 2},
+				{id: "Get", begin: 3, end: 4},
+			},
+		},
+		{
+			name: "nested region",
+			src:  "// atomix-codegen:begin-user-code Get\n// atomix-codegen:begin-user-code Put\n// atomix-codegen:end-user-code Put\n// atomix-codegen:end-user-code Get\n",
+			err:  "line 2: user code region Put begins inside region Get",
+		},
+		{
+			name: "duplicate region",
+			src:  "// atomix-codegen:begin-user-code Get\n// atomix-codegen:end-user-code Get\n// atomix-codegen:begin-user-code Get\n// atomix-codegen:end-user-code Get\n",
+			err:  "line 3: duplicate user code region Get",
+		},
+		{
+			name: "mismatched end",
+			src:  "// atomix-codegen:begin-user-code Get\n// atomix-codegen:end-user-code Put\n",
+			err:  "line 2: user code region Put ends but was never begun",
+		},
+		{
+			name: "unbalanced end",
+			src:  "// atomix-codegen:end-user-code Get\n",
+			err:  "line 1: user code region Get ends but was never begun",
+		},
+		{
+			name: "unbalanced begin",
+			src:  "package driver\n// atomix-codegen:begin-user-code Get\n",
+			err:  "line 2: user code region Get is never ended",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			regions, ids, err := parseRegions(strings.SplitAfter(test.src, "\n"))
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Fatalf("expected error %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ids) != len(test.expected) || len(regions) != len(test.expected) {
+				t.Fatalf("expected regions %v, got %v", test.expected, regions)
+			}
+			for i, expected := range test.expected {
+				if ids[i] != expected.id {
+					t.Errorf("expected region %d to be %s, got %s", i, expected.id, ids[i])
+				}
+				if actual := regions[expected.id]; actual != expected {
+					t.Errorf("expected region %v, got %v", expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestMergeUserCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		existing string
+		content  string
+		expected string
+		dropped  []string
+		err      string
+	}{
+		{
+			name:     "region preserved",
+			path:     "Makefile",
+			existing: "build:\n# atomix-codegen:begin-user-code build\n\tgo build ./cmd/driver\n# atomix-codegen:end-user-code build\n",
+			content:  "build:\n# atomix-codegen:begin-user-code build\n\tgo build ./...\n# atomix-codegen:end-user-code build\ntest:\n",
+			expected: "build:\n# atomix-codegen:begin-user-code build\n\tgo build ./cmd/driver\n# atomix-codegen:end-user-code build\ntest:\n",
+		},
+		{
+			name:     "empty region preserved",
+			path:     "Makefile",
+			existing: "build:\n# atomix-codegen:begin-user-code build\n# atomix-codegen:end-user-code build\n",
+			content:  "build:\n# atomix-codegen:begin-user-code build\n\tgo build ./...\n# atomix-codegen:end-user-code build\n",
+			expected: "build:\n# atomix-codegen:begin-user-code build\n# atomix-codegen:end-user-code build\n",
+		},
+		{
+			name:     "region added",
+			path:     "Makefile",
+			existing: "build:\n# atomix-codegen:begin-user-code build\n\tgo build ./cmd/driver\n# atomix-codegen:end-user-code build\n",
+			content:  "build:\n# atomix-codegen:begin-user-code build\n# atomix-codegen:end-user-code build\ntest:\n# atomix-codegen:begin-user-code test\n\tgo test ./...\n# atomix-codegen:end-user-code test\n",
+			expected: "build:\n# atomix-codegen:begin-user-code build\n\tgo build ./cmd/driver\n# atomix-codegen:end-user-code build\ntest:\n# atomix-codegen:begin-user-code test\n\tgo test ./...\n# atomix-codegen:end-user-code test\n",
+		},
+		{
+			name:     "region removed",
+			path:     "Makefile",
+			existing: "build:\n# atomix-codegen:begin-user-code build\n\tgo build ./cmd/driver\n# atomix-codegen:end-user-code build\n",
+			content:  "build:\n\tgo build ./...\n",
+			expected: "build:\n\tgo build ./...\n",
+			dropped:  []string{"build"},
+		},
+		{
+			name:     "region renamed",
+			path:     "Makefile",
+			existing: "build:\n# atomix-codegen:begin-user-code build\n\tgo build ./cmd/driver\n# atomix-codegen:end-user-code build\n",
+			content:  "build:\n# atomix-codegen:begin-user-code compile\n\tgo build ./...\n# atomix-codegen:end-user-code compile\n",
+			expected: "build:\n# atomix-codegen:begin-user-code compile\n\tgo build ./...\n# atomix-codegen:end-user-code compile\n",
+			dropped:  []string{"build"},
+		},
+		{
+			name:     "no regions in existing file",
+			path:     "Makefile",
+			existing: "build:\n\tgo build ./cmd/driver\n",
+			content:  "build:\n# atomix-codegen:begin-user-code build\n\tgo build ./...\n# atomix-codegen:end-user-code build\n",
+			expected: "build:\n# atomix-codegen:begin-user-code build\n\tgo build ./...\n# atomix-codegen:end-user-code build\n",
+		},
+		{
+			name:     "unused generated import pruned",
+			path:     "driver.go",
+			existing: "package driver\n\nimport (\n\t// atomix-codegen:begin-user-code imports\n\t\"errors\"\n\t// atomix-codegen:end-user-code imports\n)\n\nfunc get() error {\n\t// atomix-codegen:begin-user-code get\n\treturn errors.New(\"get\")\n\t// atomix-codegen:end-user-code get\n}\n",
+			content:  "package driver\n\nimport (\n\t\"fmt\"\n\t// atomix-codegen:begin-user-code imports\n\t// atomix-codegen:end-user-code imports\n)\n\nfunc get() error {\n\t// atomix-codegen:begin-user-code get\n\treturn fmt.Errorf(\"get\")\n\t// atomix-codegen:end-user-code get\n}\n",
+			expected: "package driver\n\nimport (\n\t// atomix-codegen:begin-user-code imports\n\t\"errors\"\n\t// atomix-codegen:end-user-code imports\n)\n\nfunc get() error {\n\t// atomix-codegen:begin-user-code get\n\treturn errors.New(\"get\")\n\t// atomix-codegen:end-user-code get\n}\n",
+		},
+		{
+			name:     "unbalanced markers in existing file",
+			path:     "Makefile",
+			existing: "build:\n# atomix-codegen:begin-user-code build\n\tgo build ./cmd/driver\n",
+			content:  "build:\n# atomix-codegen:begin-user-code build\n\tgo build ./...\n# atomix-codegen:end-user-code build\n",
+			err:      "line 2: user code region build is never ended",
+		},
+		{
+			name:     "unbalanced markers in generated content",
+			path:     "Makefile",
+			existing: "build:\n# atomix-codegen:begin-user-code build\n\tgo build ./cmd/driver\n# atomix-codegen:end-user-code build\n",
+			content:  "build:\n# atomix-codegen:end-user-code build\n",
+			err:      "generated output: line 2: user code region build ends but was never begun",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			merged, dropped, err := mergeUserCode(test.path, []byte(test.existing), []byte(test.content))
+			if test.err != "" {
+				if err == nil || err.Error() != test.err {
+					t.Fatalf("expected error %q, got %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(merged) != test.expected {
+				t.Errorf("expected merged content:\n%s\ngot:\n%s", test.expected, merged)
+			}
+			if strings.Join(dropped, ",") != strings.Join(test.dropped, ",") {
+				t.Errorf("expected dropped regions %v, got %v", test.dropped, dropped)
+			}
+		})
+	}
+}
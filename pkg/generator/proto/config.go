@@ -4,16 +4,28 @@
 
 package proto
 
+import "github.com/atomix/codegen/pkg/generator/output"
+
+type Mode string
+
+const (
+	ProtocMode    Mode = "protoc"
+	InProcessMode Mode = "in-process"
+)
+
 type Config struct {
-	Input     InputConfig      `yaml:"input,omitempty"`
-	Output    OutputConfig     `yaml:"output,omitempty"`
-	Templates []TemplateConfig `yaml:"templates,omitempty"`
+	Mode        Mode             `yaml:"mode,omitempty"`
+	Input       InputConfig      `yaml:"input,omitempty"`
+	Output      OutputConfig     `yaml:"output,omitempty"`
+	Templates   []TemplateConfig `yaml:"templates,omitempty"`
+	Boilerplate string           `yaml:"boilerplate,omitempty"`
 }
 
 type InputConfig struct {
-	Repo  InputRepo `yaml:"repo,omitempty"`
-	Path  string    `yaml:"path,omitempty"`
-	Files []string  `yaml:"files,omitempty"`
+	Repo    InputRepo `yaml:"repo,omitempty"`
+	Path    string    `yaml:"path,omitempty"`
+	Files   []string  `yaml:"files,omitempty"`
+	Include []string  `yaml:"include,omitempty"`
 }
 
 type InputRepo struct {
@@ -33,10 +45,38 @@ type ModuleConfig struct {
 
 type TemplateType string
 
+const (
+	ServiceTemplateType TemplateType = "service"
+	FileTemplateType    TemplateType = "file"
+	MessageTemplateType TemplateType = "message"
+	EnumTemplateType    TemplateType = "enum"
+	RequestTemplateType TemplateType = "request"
+)
+
 type TemplateConfig struct {
-	Name   string               `yaml:"name,omitempty"`
-	Path   string               `yaml:"path,omitempty"`
-	Output TemplateOutputConfig `yaml:"output,omitempty"`
+	Name    string               `yaml:"name,omitempty"`
+	Type    TemplateType         `yaml:"type,omitempty"`
+	Path    string               `yaml:"path,omitempty"`
+	Output  TemplateOutputConfig `yaml:"output,omitempty"`
+	Policy  output.Policy        `yaml:"policy,omitempty"`
+	Content string               `yaml:"-"`
+}
+
+// TemplatePath returns the path of the template
+func (c *TemplateConfig) TemplatePath() string {
+	return c.Path
+}
+
+// SetContent sets the content of the template, which is otherwise read from the template path
+func (c *TemplateConfig) SetContent(content string) {
+	c.Content = content
+}
+
+// SetDefaultPolicy sets the write policy of the template's output, unless the policy is already set
+func (c *TemplateConfig) SetDefaultPolicy(policy output.Policy) {
+	if c.Policy == "" {
+		c.Policy = policy
+	}
 }
 
 type TemplateOutputConfig struct {
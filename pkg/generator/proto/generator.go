@@ -7,9 +7,17 @@ package proto
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/atomix/codegen/pkg/exec"
+	"github.com/atomix/codegen/pkg/generator/output"
+	"github.com/atomix/codegen/pkg/generator/proto/include"
+	"github.com/atomix/codegen/pkg/generator/proto/plugin"
+	"github.com/atomix/codegen/pkg/generator/template"
 	"github.com/bmatcuk/doublestar/v4"
+	plugin_go "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -21,6 +29,9 @@ func Generate(config Config, values interface{}) error {
 }
 
 func NewGenerator(config Config) *Generator {
+	if config.Mode == "" {
+		config.Mode = ProtocMode
+	}
 	if config.Input.Path == "" {
 		config.Input.Path = "."
 	}
@@ -68,19 +79,27 @@ func (g *Generator) Generate(values interface{}) error {
 			}
 		}
 	}
-	return NewDir(g, dir).Generate(values)
+
+	includeDir, err := include.TempDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(includeDir)
+	return NewDir(g, dir, includeDir).Generate(values)
 }
 
-func NewDir(parent *Generator, dir string) *DirGenerator {
+func NewDir(parent *Generator, dir string, includeDir string) *DirGenerator {
 	return &DirGenerator{
-		Generator: parent,
-		Dir:       dir,
+		Generator:  parent,
+		Dir:        dir,
+		IncludeDir: includeDir,
 	}
 }
 
 type DirGenerator struct {
 	*Generator
-	Dir string
+	Dir        string
+	IncludeDir string
 }
 
 func (g *DirGenerator) Generate(values interface{}) error {
@@ -141,46 +160,150 @@ type FilesGenerator struct {
 }
 
 func (g *FilesGenerator) Generate(values interface{}) error {
+	// The plugin's output does not record which template rendered each file, so templates with
+	// different write policies are rendered by separate plugin invocations
+	var policies []output.Policy
+	templates := make(map[output.Policy][]TemplateConfig)
 	for _, template := range g.Config.Templates {
-		if err := NewTemplate(g, template).Generate(values); err != nil {
+		if _, ok := templates[template.Policy]; !ok {
+			policies = append(policies, template.Policy)
+		}
+		templates[template.Policy] = append(templates[template.Policy], template)
+	}
+	for _, policy := range policies {
+		if err := NewTemplates(g, policy, templates[policy]...).Generate(values); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func NewTemplate(parent *FilesGenerator, template TemplateConfig) *TemplateGenerator {
-	return &TemplateGenerator{
+func NewTemplates(parent *FilesGenerator, policy output.Policy, templates ...TemplateConfig) *TemplatesGenerator {
+	return &TemplatesGenerator{
 		FilesGenerator: parent,
-		Template:       template,
+		Policy:         policy,
+		Templates:      templates,
 	}
 }
 
-type TemplateGenerator struct {
+// TemplatesGenerator renders all the templates sharing a write policy for a set of files in a single plugin invocation
+type TemplatesGenerator struct {
 	*FilesGenerator
-	Template TemplateConfig
+	Policy    output.Policy
+	Templates []TemplateConfig
 }
 
-func (g *TemplateGenerator) Generate(values interface{}) error {
+func (g *TemplatesGenerator) Generate(values interface{}) error {
+	if len(g.Templates) == 0 {
+		return nil
+	}
+
 	var protoPath []string
 	protoPath = append(protoPath, filepath.Join(g.Dir, g.Config.Input.Path))
-	protoPath = append(protoPath, filepath.Join(os.Getenv("GOPATH"), "src/github.com/gogo/protobuf"))
+	protoPath = append(protoPath, g.Config.Input.Include...)
+	protoPath = append(protoPath, g.IncludeDir)
+
+	boilerplate, err := template.ReadBoilerplate(g.Config.Boilerplate)
+	if err != nil {
+		return err
+	}
 
-	bytes, err := json.Marshal(values)
+	spec, err := GetParameter(g.Templates, values, boilerplate)
 	if err != nil {
 		return err
 	}
 
+	switch g.Config.Mode {
+	case ProtocMode:
+		return g.protoc(protoPath, spec)
+	case InProcessMode:
+		return g.inProcess(protoPath, spec)
+	default:
+		return fmt.Errorf("unknown mode '%s'", g.Config.Mode)
+	}
+}
+
+// templateSpec is the JSON encoding of a template passed to the protoc-gen-service plugin
+type templateSpec struct {
+	Name    string       `json:"name,omitempty"`
+	Type    TemplateType `json:"type,omitempty"`
+	Path    string       `json:"path"`
+	Content string       `json:"content,omitempty"`
+	Output  string       `json:"output"`
+}
+
+// GetParameter encodes the given templates, values and boilerplate as a protoc-gen-service plugin parameter
+func GetParameter(templates []TemplateConfig, values interface{}, boilerplate string) (string, error) {
+	specs := make([]templateSpec, 0, len(templates))
+	for _, template := range templates {
+		if template.Type == "" {
+			template.Type = ServiceTemplateType
+		}
+		specs = append(specs, templateSpec{
+			Name:    template.Name,
+			Type:    template.Type,
+			Path:    template.Path,
+			Content: template.Content,
+			Output:  template.Output.PathTemplate,
+		})
+	}
+
+	templatesBytes, err := json.Marshal(specs)
+	if err != nil {
+		return "", err
+	}
+	valuesBytes, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+
 	var specArgs []string
-	specArgs = append(specArgs, fmt.Sprintf("template=%s", g.Template.Path))
-	specArgs = append(specArgs, fmt.Sprintf("output=%s", base64.RawURLEncoding.EncodeToString([]byte(g.Template.Output.PathTemplate))))
-	specArgs = append(specArgs, fmt.Sprintf("values=%s", base64.RawURLEncoding.EncodeToString(bytes)))
-	spec := strings.Join(specArgs, ",")
+	specArgs = append(specArgs, fmt.Sprintf("templates=%s", base64.RawURLEncoding.EncodeToString(templatesBytes)))
+	specArgs = append(specArgs, fmt.Sprintf("values=%s", base64.RawURLEncoding.EncodeToString(valuesBytes)))
+	if boilerplate != "" {
+		specArgs = append(specArgs, fmt.Sprintf("boilerplate=%s", base64.RawURLEncoding.EncodeToString([]byte(boilerplate))))
+	}
+	return strings.Join(specArgs, ","), nil
+}
+
+func (g *TemplatesGenerator) protoc(protoPath []string, spec string) error {
+	return output.GenerateDir(g.Config.Output.Path, g.Policy, func(outDir string) error {
+		var protoArgs []string
+		protoArgs = append(protoArgs, fmt.Sprintf("-I=%s", strings.Join(protoPath, ":")))
+		protoArgs = append(protoArgs, fmt.Sprintf("--atom_out=%s:%s", spec, outDir))
+		protoArgs = append(protoArgs, g.Files...)
+		return exec.Run("protoc", protoArgs...)
+	})
+}
+
+func (g *TemplatesGenerator) inProcess(protoPath []string, spec string) error {
+	parser := protoparse.Parser{
+		ImportPaths:           protoPath,
+		IncludeSourceCodeInfo: true,
+	}
+	files, err := parser.ParseFiles(g.Files...)
+	if err != nil {
+		return err
+	}
 
-	var protoArgs []string
-	protoArgs = append(protoArgs, fmt.Sprintf("-I=%s", strings.Join(protoPath, ":")))
-	protoArgs = append(protoArgs, fmt.Sprintf("--atom_out=%s:%s", spec, g.Config.Output.Path))
-	protoArgs = append(protoArgs, g.Files...)
+	request := &plugin_go.CodeGeneratorRequest{
+		FileToGenerate: g.Files,
+		Parameter:      &spec,
+		ProtoFile:      desc.ToFileDescriptorSet(files...).File,
+	}
+	response, err := plugin.Run(request)
+	if err != nil {
+		return err
+	}
+	if response.Error != nil {
+		return errors.New(response.GetError())
+	}
 
-	return exec.Run("protoc", protoArgs...)
+	for _, file := range response.File {
+		path := filepath.Join(g.Config.Output.Path, file.GetName())
+		if err := output.Write(path, []byte(file.GetContent()), g.Policy); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package include
+
+import (
+	"embed"
+	codegenv1 "github.com/atomix/codegen/api/atomix/codegen/v1"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+const optionsPath = "atomix/codegen/v1/options.proto"
+
+// files contains gogo.proto and the google/protobuf well-known types
+//
+//go:embed gogoproto google
+var files embed.FS
+
+// TempDir writes the embedded include files to a new temporary directory
+// The caller is responsible for removing the directory when done
+func TempDir() (string, error) {
+	dir, err := ioutil.TempDir("", "atomix-codegen-include")
+	if err != nil {
+		return "", err
+	}
+	if err := Write(dir); err != nil {
+		_ = os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+// Write writes the embedded include files to the given directory
+func Write(dir string) error {
+	err := fs.WalkDir(files, ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		bytes, err := files.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		return writeFile(filepath.Join(dir, path), bytes)
+	})
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(dir, optionsPath), codegenv1.OptionsProto)
+}
+
+func writeFile(path string, bytes []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, bytes, 0644)
+}
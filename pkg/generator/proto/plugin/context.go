@@ -0,0 +1,754 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	codegenv1 "github.com/atomix/codegen/api/atomix/codegen/v1"
+	"github.com/atomix/codegen/pkg/generator/template"
+	"github.com/golang/protobuf/proto"
+	pgs "github.com/lyft/protoc-gen-star"
+	pgsgo "github.com/lyft/protoc-gen-star/lang/go"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	templatesParamKey   = "templates"
+	templateParamKey    = "template"
+	typeParamKey        = "type"
+	outputParamKey      = "output"
+	valuesParamKey      = "values"
+	boilerplateParamKey = "boilerplate"
+)
+
+const (
+	serviceTemplateType = "service"
+	fileTemplateType    = "file"
+	messageTemplateType = "message"
+	enumTemplateType    = "enum"
+	requestTemplateType = "request"
+)
+
+// TemplateSpec is the specification of a template passed to the plugin. The template is parsed from the
+// content if set, e.g. for templates embedded in a generator binary, and otherwise read from the path
+type TemplateSpec struct {
+	Name    string `json:"name,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Path    string `json:"path"`
+	Content string `json:"content,omitempty"`
+	Output  string `json:"output"`
+}
+
+// newContext creates a new metadata context
+func newContext(ctx pgsgo.Context) *Context {
+	return &Context{
+		ctx: ctx,
+	}
+}
+
+// Context is the code generation context
+type Context struct {
+	ctx pgsgo.Context
+}
+
+// Templates returns the templates to render
+func (c *Context) Templates() ([]TemplateSpec, error) {
+	if encodedTemplates := c.ctx.Params().Str(templatesParamKey); encodedTemplates != "" {
+		decodedTemplates, err := base64.RawURLEncoding.DecodeString(encodedTemplates)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode templates: %w", err)
+		}
+		var templates []TemplateSpec
+		if err := json.Unmarshal(decodedTemplates, &templates); err != nil {
+			return nil, fmt.Errorf("failed to decode templates: %w", err)
+		}
+		for i, template := range templates {
+			if template.Type == "" {
+				templates[i].Type = serviceTemplateType
+			}
+		}
+		return templates, nil
+	}
+
+	// Fall back to the single template parameters
+	outputTemplate := c.ctx.Params().Str(outputParamKey)
+	decodedTemplate, err := base64.RawURLEncoding.DecodeString(outputTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode output path template: %w", err)
+	}
+	templateType := c.ctx.Params().Str(typeParamKey)
+	if templateType == "" {
+		templateType = serviceTemplateType
+	}
+	return []TemplateSpec{
+		{
+			Name:   c.ctx.Params().Str(templateParamKey),
+			Type:   templateType,
+			Path:   c.ctx.Params().Str(templateParamKey),
+			Output: string(decodedTemplate),
+		},
+	}, nil
+}
+
+// OutputPath renders the output path for the given template
+func (c *Context) OutputPath(spec TemplateSpec, params Params) (string, error) {
+	template, err := template.New(outputParamKey).Parse(spec.Output)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse output path template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := template.Execute(&buf, params); err != nil {
+		return "", fmt.Errorf("failed to render output path: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func (c *Context) ImportPath(entity pgs.Entity) string {
+	return c.ctx.ImportPath(entity).String()
+}
+
+func (c *Context) Values() (map[string]interface{}, error) {
+	encodedValues := c.ctx.Params().Str(valuesParamKey)
+	decodedValues, err := base64.RawURLEncoding.DecodeString(encodedValues)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]interface{})
+	if err := json.Unmarshal(decodedValues, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// Boilerplate returns the license boilerplate to prepend to generated files
+func (c *Context) Boilerplate() (string, error) {
+	decodedBoilerplate, err := base64.RawURLEncoding.DecodeString(c.ctx.Params().Str(boilerplateParamKey))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode boilerplate: %w", err)
+	}
+	return string(decodedBoilerplate), nil
+}
+
+// FilePath returns the output path for the given entity
+func (c *Context) FilePath(entity pgs.Entity, file string) string {
+	path := c.ctx.Params().OutputPath()
+	if path == "" {
+		path = c.ctx.OutputPath(entity).Dir().String()
+	}
+	return filepath.Join(path, file)
+}
+
+// FileParams extracts the file parameters for the given entity
+func (c *Context) FileParams(entity pgs.Entity) FileParams {
+	return FileParams{
+		Name:     entity.File().InputPath().Base(),
+		BaseName: entity.File().InputPath().BaseName(),
+		Path:     entity.File().InputPath().String(),
+	}
+}
+
+func (c *Context) PackageParams(entity pgs.Entity) PackageParams {
+	return PackageParams{
+		Name: c.ctx.PackageName(entity).String(),
+		Path: c.ImportPath(entity),
+	}
+}
+
+func (c *Context) EntityParams(entity pgs.Entity) EntityParams {
+	return EntityParams{
+		File:    c.FileParams(entity),
+		Package: c.PackageParams(entity),
+	}
+}
+
+func (c *Context) findMessage(typeName string, packages map[string]pgs.Package) (pgs.Message, bool) {
+	for _, pkg := range packages {
+		for _, file := range pkg.Files() {
+			for _, msg := range file.Messages() {
+				if msg.FullyQualifiedName() == typeName {
+					return msg, true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+func (c *Context) FieldParams(field pgs.Field) (FieldParams, error) {
+	return c.fieldParams(field, make(map[string]bool))
+}
+
+func (c *Context) fieldParams(field pgs.Field, visited map[string]bool) (FieldParams, error) {
+	fieldName, err := c.FieldName(field)
+	if err != nil {
+		return FieldParams{}, err
+	}
+	fieldType, err := c.FieldTypeParams(field)
+	if err != nil {
+		return FieldParams{}, err
+	}
+	params := FieldParams{
+		Name:   field.Name().String(),
+		Number: field.Descriptor().GetNumber(),
+		Type:   fieldType,
+		Path: []PathParams{
+			{
+				Name: fieldName,
+				Type: fieldType,
+			},
+		},
+		HasPresence: field.HasPresence(),
+		IsOptional:  field.HasOptionalKeyword(),
+	}
+	if field.Type().IsEmbed() && !visited[field.Type().Embed().FullyQualifiedName()] {
+		message, err := c.messageParams(field.Type().Embed(), visited)
+		if err != nil {
+			return FieldParams{}, err
+		}
+		params.Message = &message
+	}
+	return params, nil
+}
+
+func (c *Context) MessageParams(message pgs.Message) (MessageParams, error) {
+	return c.messageParams(message, make(map[string]bool))
+}
+
+func (c *Context) messageParams(message pgs.Message, visited map[string]bool) (MessageParams, error) {
+	visited[message.FullyQualifiedName()] = true
+	defer delete(visited, message.FullyQualifiedName())
+
+	fields := make([]FieldParams, 0, len(message.Fields()))
+	fieldIndexes := make(map[string]int)
+	for i, field := range message.Fields() {
+		fieldParams, err := c.fieldParams(field, visited)
+		if err != nil {
+			return MessageParams{}, fmt.Errorf("field %s: %w", field.Name(), err)
+		}
+		fieldParams.Index = i
+		fields = append(fields, fieldParams)
+		fieldIndexes[fieldParams.Name] = i
+	}
+
+	// Link each oneof member to its oneof so templates can find the sibling fields
+	oneofs := make([]OneofParams, len(message.RealOneOfs()))
+	for i, oneof := range message.RealOneOfs() {
+		oneofs[i] = OneofParams{
+			Name:  oneof.Name().String(),
+			Index: i,
+		}
+		for _, field := range oneof.Fields() {
+			fields[fieldIndexes[field.Name().String()]].Oneof = &oneofs[i]
+		}
+	}
+
+	// The members are copied once linked, so the fields listed by a oneof refer back to it as well
+	for i, oneof := range message.RealOneOfs() {
+		oneofs[i].Fields = make([]FieldParams, 0, len(oneof.Fields()))
+		for _, field := range oneof.Fields() {
+			oneofs[i].Fields = append(oneofs[i].Fields, fields[fieldIndexes[field.Name().String()]])
+		}
+	}
+	return MessageParams{
+		Type:    c.MessageTypeParams(message),
+		Comment: c.Comment(message),
+		Fields:  fields,
+		Oneofs:  oneofs,
+	}, nil
+}
+
+// Comment returns the leading comments for the given entity
+func (c *Context) Comment(entity pgs.Entity) string {
+	// Source code info is only available for entities defined in the target files
+	if info := entity.SourceCodeInfo(); info != nil {
+		return info.LeadingComments()
+	}
+	return ""
+}
+
+// EnumParams extracts the metadata for the given enum
+func (c *Context) EnumParams(enum pgs.Enum) EnumParams {
+	values := make([]EnumValueParams, 0, len(enum.Values()))
+	for _, value := range enum.Values() {
+		values = append(values, EnumValueParams{
+			Type:    c.EnumValueTypeParams(value),
+			Comment: c.Comment(value),
+			Number:  value.Value(),
+		})
+	}
+	return EnumParams{
+		Type:    c.EnumTypeParams(enum),
+		Comment: c.Comment(enum),
+		Values:  values,
+	}
+}
+
+// EnumTypeParams extracts the type metadata for the given enum
+func (c *Context) EnumTypeParams(enum pgs.Enum) TypeParams {
+	values := make([]TypeParams, 0, len(enum.Values()))
+	for _, value := range enum.Values() {
+		values = append(values, c.EnumValueTypeParams(value))
+	}
+	return TypeParams{
+		EntityParams: c.EntityParams(enum),
+		Name:         pgsgo.PGGUpperCamelCase(enum.Name()).String(),
+		FullName:     getFullName(enum),
+		GoName:       c.ctx.Name(enum).String(),
+		GoImportPath: c.ImportPath(enum),
+		IsEnum:       true,
+		Values:       values,
+	}
+}
+
+// MessageTypeParams extracts the type metadata for the given message
+func (c *Context) MessageTypeParams(message pgs.Message) TypeParams {
+	typeParams := TypeParams{
+		EntityParams: c.EntityParams(message),
+		Name:         pgsgo.PGGUpperCamelCase(message.Name()).String(),
+		FullName:     getFullName(message),
+		GoName:       c.ctx.Name(message).String(),
+		GoImportPath: c.ImportPath(message),
+		IsMessage:    true,
+	}
+	setWellKnownType(&typeParams, c.WellKnownTypeParams(message))
+	return typeParams
+}
+
+// setWellKnownType sets the well-known type metadata on the given type, which is generated as the Go type
+// of the well-known type rather than in the package of the proto file
+func setWellKnownType(typeParams *TypeParams, wkt *WellKnownTypeParams) {
+	typeParams.IsWellKnown = wkt != nil
+	typeParams.WellKnown = wkt
+	if wkt != nil {
+		typeParams.GoName = wkt.GoType[strings.LastIndex(wkt.GoType, ".")+1:]
+		typeParams.GoImportPath = wkt.GoImportPath
+	}
+}
+
+// setGoType overrides the Go type of the given field's type with a gogoproto cast or custom type. The type is
+// either qualified with its import path or declared in the Go package generated for the field's proto file.
+func (c *Context) setGoType(typeParams *TypeParams, field pgs.Field, goType string) {
+	typeParams.Name = goType
+	if i := strings.LastIndex(goType, "."); i >= 0 {
+		typeParams.GoName = goType[i+1:]
+		typeParams.GoImportPath = goType[:i]
+	} else {
+		typeParams.GoName = goType
+		typeParams.GoImportPath = c.ImportPath(field)
+	}
+}
+
+// getFullName returns the fully qualified proto name of the given entity without the leading dot
+func getFullName(entity pgs.Entity) string {
+	return strings.TrimPrefix(entity.FullyQualifiedName(), ".")
+}
+
+// WellKnownTypeParams extracts the well-known type metadata for the given message
+func (c *Context) WellKnownTypeParams(message pgs.Message) *WellKnownTypeParams {
+	if !message.IsWellKnown() {
+		return nil
+	}
+	wkt := message.WellKnownType()
+	return &WellKnownTypeParams{
+		Name:         wkt.Name().String(),
+		GoType:       fmt.Sprintf("types.%s", wkt.Name()),
+		GoImportPath: gogoTypesImportPath,
+		IsAny:        wkt == pgs.AnyWKT,
+		IsDuration:   wkt == pgs.DurationWKT,
+		IsEmpty:      wkt == pgs.EmptyWKT,
+		IsStruct:     wkt == pgs.StructWKT,
+		IsTimestamp:  wkt == pgs.TimestampWKT,
+		IsValue:      wkt == pgs.ValueWKT,
+		IsListValue:  wkt == pgs.ListValueWKT,
+		IsWrapper:    wrapperTypes[wkt] != "",
+	}
+}
+
+// wellKnownFieldTypeParams extracts the well-known type metadata for the given field,
+// applying the gogoproto options that map well-known types to standard Go types
+func (c *Context) wellKnownFieldTypeParams(field pgs.Field, message pgs.Message) (*WellKnownTypeParams, error) {
+	wkt := c.WellKnownTypeParams(message)
+	if wkt == nil {
+		return nil, nil
+	}
+
+	switch {
+	case wkt.IsTimestamp:
+		stdtime, err := getStdtime(field)
+		if err != nil {
+			return nil, err
+		} else if stdtime != nil && *stdtime {
+			wkt.GoType = "time.Time"
+			wkt.GoImportPath = "time"
+			wkt.IsStdType = true
+		}
+	case wkt.IsDuration:
+		stdduration, err := getStdduration(field)
+		if err != nil {
+			return nil, err
+		} else if stdduration != nil && *stdduration {
+			wkt.GoType = "time.Duration"
+			wkt.GoImportPath = "time"
+			wkt.IsStdType = true
+		}
+	case wkt.IsWrapper:
+		wktpointer, err := getWktpointer(field)
+		if err != nil {
+			return nil, err
+		} else if wktpointer != nil && *wktpointer {
+			wkt.GoType = wrapperTypes[message.WellKnownType()]
+			wkt.GoImportPath = ""
+			wkt.IsStdType = true
+		}
+	}
+	return wkt, nil
+}
+
+const gogoTypesImportPath = "github.com/gogo/protobuf/types"
+
+// wrapperTypes maps the wrapper well-known types to the Go types they wrap
+var wrapperTypes = map[pgs.WellKnownType]string{
+	pgs.DoubleValueWKT: "float64",
+	pgs.FloatValueWKT:  "float32",
+	pgs.Int64ValueWKT:  "int64",
+	pgs.UInt64ValueWKT: "uint64",
+	pgs.Int32ValueWKT:  "int32",
+	pgs.UInt32ValueWKT: "uint32",
+	pgs.BoolValueWKT:   "bool",
+	pgs.StringValueWKT: "string",
+	pgs.BytesValueWKT:  "[]byte",
+}
+
+func getProtoTypeName(protoType pgs.ProtoType) string {
+	switch protoType {
+	case pgs.BytesT:
+		return "[]byte"
+	case pgs.StringT:
+		return "string"
+	case pgs.Int32T, pgs.SInt32, pgs.SFixed32:
+		return "int32"
+	case pgs.Int64T, pgs.SInt64, pgs.SFixed64:
+		return "int64"
+	case pgs.UInt32T, pgs.Fixed32T:
+		return "uint32"
+	case pgs.UInt64T, pgs.Fixed64T:
+		return "uint64"
+	case pgs.FloatT:
+		return "float32"
+	case pgs.DoubleT:
+		return "float64"
+	case pgs.BoolT:
+		return "bool"
+	}
+	return ""
+}
+
+// FieldName computes the name for the given field
+func (c *Context) FieldName(field pgs.Field) (string, error) {
+	customName, err := getCustomName(field)
+	if err != nil {
+		return "", err
+	} else if customName != nil {
+		return *customName, nil
+	}
+	embed, err := getEmbed(field)
+	if err != nil {
+		return "", err
+	} else if embed != nil && *embed {
+		return pgsgo.PGGUpperCamelCase(field.Type().Embed().Name()).String(), nil
+	}
+	name := field.Name()
+	if name == "size" {
+		name = "size_"
+	}
+	return pgsgo.PGGUpperCamelCase(name).String(), nil
+}
+
+// RawFieldTypeParams extracts the raw type metadata for the given field
+func (c *Context) RawFieldTypeParams(field pgs.Field) (TypeParams, error) {
+	if field.Type().IsMap() {
+		return c.MapFieldTypeParams(field)
+	}
+	if field.Type().IsRepeated() {
+		return c.RepeatedFieldTypeParams(field)
+	}
+	if field.Type().IsEmbed() {
+		return c.MessageFieldTypeParams(field)
+	}
+	if field.Type().IsEnum() {
+		return c.EnumFieldTypeParams(field), nil
+	}
+	return c.ScalarTypeParams(field, field.Type().ProtoType()), nil
+}
+
+// FieldTypeParams extracts the type metadata for the given field
+func (c *Context) FieldTypeParams(field pgs.Field) (TypeParams, error) {
+	if field.Type().IsMap() {
+		return c.MapFieldTypeParams(field)
+	}
+	if field.Type().IsRepeated() {
+		return c.RepeatedFieldTypeParams(field)
+	}
+	if field.Type().IsEmbed() {
+		return c.MessageFieldTypeParams(field)
+	}
+	if field.Type().IsEnum() {
+		return c.EnumFieldTypeParams(field), nil
+	}
+
+	typeParams := c.ScalarTypeParams(field, field.Type().ProtoType())
+	castType, err := getCastType(field)
+	if err != nil {
+		return TypeParams{}, err
+	} else if castType != nil {
+		c.setGoType(&typeParams, field, *castType)
+		typeParams.IsCast = true
+	}
+	return typeParams, nil
+}
+
+// ScalarTypeParams extracts the type metadata for the given scalar type
+func (c *Context) ScalarTypeParams(entity pgs.Entity, protoType pgs.ProtoType) TypeParams {
+	return TypeParams{
+		EntityParams: c.EntityParams(entity),
+		Name:         getProtoTypeName(protoType),
+		GoName:       getProtoTypeName(protoType),
+		IsScalar:     true,
+		IsBytes:      protoType == pgs.BytesT,
+		IsString:     protoType == pgs.StringT,
+		IsInt32:      protoType == pgs.Int32T || protoType == pgs.SInt32 || protoType == pgs.SFixed32,
+		IsInt64:      protoType == pgs.Int64T || protoType == pgs.SInt64 || protoType == pgs.SFixed64,
+		IsUint32:     protoType == pgs.UInt32T || protoType == pgs.Fixed32T,
+		IsUint64:     protoType == pgs.UInt64T || protoType == pgs.Fixed64T,
+		IsFloat:      protoType == pgs.FloatT,
+		IsDouble:     protoType == pgs.DoubleT,
+		IsBool:       protoType == pgs.BoolT,
+	}
+}
+
+// MessageFieldTypeParams extracts the type metadata for the given message field
+func (c *Context) MessageFieldTypeParams(field pgs.Field) (TypeParams, error) {
+	castType, err := getCastType(field)
+	if err != nil {
+		return TypeParams{}, err
+	}
+	return c.embedTypeParams(field, field.Type().Embed(), castType)
+}
+
+// embedTypeParams extracts the type metadata for a message embedded in the given field
+func (c *Context) embedTypeParams(field pgs.Field, message pgs.Message, castType *string) (TypeParams, error) {
+	typeParams := c.MessageTypeParams(message)
+	typeParams.IsPointer = true
+
+	wellKnownType, err := c.wellKnownFieldTypeParams(field, message)
+	if err != nil {
+		return TypeParams{}, err
+	}
+	setWellKnownType(&typeParams, wellKnownType)
+
+	if castType != nil {
+		c.setGoType(&typeParams, field, *castType)
+		typeParams.IsCast = true
+	}
+
+	customType, err := getCustomType(field)
+	if err != nil {
+		return TypeParams{}, err
+	} else if customType != nil {
+		c.setGoType(&typeParams, field, *customType)
+	}
+
+	nullable, err := getNullable(field)
+	if err != nil {
+		return TypeParams{}, err
+	} else if nullable != nil {
+		typeParams.IsPointer = *nullable
+	}
+	return typeParams, nil
+}
+
+// RepeatedFieldTypeParams extracts the type metadata for the given repeated field
+func (c *Context) RepeatedFieldTypeParams(field pgs.Field) (TypeParams, error) {
+	elementTypeParams, err := c.FieldElementTypeParams(field)
+	if err != nil {
+		return TypeParams{}, err
+	}
+	elementTypeParams.IsRepeated = true
+	return elementTypeParams, nil
+}
+
+// MapFieldTypeParams extracts the type metadata for the given map field
+func (c *Context) MapFieldTypeParams(field pgs.Field) (TypeParams, error) {
+	keyTypeParams, err := c.FieldKeyTypeParams(field)
+	if err != nil {
+		return TypeParams{}, err
+	}
+	valueTypeParams, err := c.FieldValueTypeParams(field)
+	if err != nil {
+		return TypeParams{}, err
+	}
+	return TypeParams{
+		EntityParams: c.EntityParams(field),
+		Name:         "map",
+		IsMap:        true,
+		KeyType:      &keyTypeParams,
+		ValueType:    &valueTypeParams,
+	}, nil
+}
+
+// FieldKeyTypeParams extracts the key type metadata for the given map field
+func (c *Context) FieldKeyTypeParams(field pgs.Field) (TypeParams, error) {
+	// Map keys can only be integral or string types
+	typeParams := c.ScalarTypeParams(field, field.Type().Key().ProtoType())
+	castKey, err := getCastKey(field)
+	if err != nil {
+		return TypeParams{}, err
+	} else if castKey != nil {
+		c.setGoType(&typeParams, field, *castKey)
+		typeParams.IsCast = true
+	}
+	return typeParams, nil
+}
+
+// FieldValueTypeParams extracts the value type metadata for the given map field
+func (c *Context) FieldValueTypeParams(field pgs.Field) (TypeParams, error) {
+	castValue, err := getCastValue(field)
+	if err != nil {
+		return TypeParams{}, err
+	}
+	return c.elementTypeParams(field, field.Type().Element(), castValue)
+}
+
+// FieldElementTypeParams extracts the element type metadata for the given repeated field
+func (c *Context) FieldElementTypeParams(field pgs.Field) (TypeParams, error) {
+	castType, err := getCastType(field)
+	if err != nil {
+		return TypeParams{}, err
+	}
+	return c.elementTypeParams(field, field.Type().Element(), castType)
+}
+
+// elementTypeParams extracts the type metadata for a repeated element or map value
+func (c *Context) elementTypeParams(field pgs.Field, element pgs.FieldTypeElem, castType *string) (TypeParams, error) {
+	if element.IsEmbed() {
+		return c.embedTypeParams(field, element.Embed(), castType)
+	}
+
+	var typeParams TypeParams
+	if element.IsEnum() {
+		typeParams = c.EnumTypeParams(element.Enum())
+	} else {
+		typeParams = c.ScalarTypeParams(field, element.ProtoType())
+	}
+
+	if castType != nil {
+		c.setGoType(&typeParams, field, *castType)
+		typeParams.IsCast = true
+	}
+
+	customType, err := getCustomType(field)
+	if err != nil {
+		return TypeParams{}, err
+	} else if customType != nil {
+		c.setGoType(&typeParams, field, *customType)
+	}
+	return typeParams, nil
+}
+
+// EnumFieldTypeParams extracts the type metadata for the given enum field
+func (c *Context) EnumFieldTypeParams(field pgs.Field) TypeParams {
+	return c.EnumTypeParams(field.Type().Enum())
+}
+
+// EnumValueTypeParams extracts the type metadata for the given enum value
+func (c *Context) EnumValueTypeParams(enumValue pgs.EnumValue) TypeParams {
+	return TypeParams{
+		EntityParams: c.EntityParams(enumValue),
+		Name:         pgsgo.PGGUpperCamelCase(enumValue.Name()).String(),
+		IsEnumValue:  true,
+	}
+}
+
+// HeadersField finds the field annotated as the headers in the given message
+func (c *Context) HeadersField(message pgs.Message) (*FieldRefParams, error) {
+	return c.findAnnotatedField(message, getExtensionDesc(codegenv1.E_Headers), make(map[string]bool))
+}
+
+// PartitionKeyField finds the field annotated as the partition key in the given message
+func (c *Context) PartitionKeyField(message pgs.Message) (*FieldRefParams, error) {
+	return c.findAnnotatedField(message, getExtensionDesc(codegenv1.E_PartitionKey), make(map[string]bool))
+}
+
+func (c *Context) findAnnotatedField(message pgs.Message, extension *proto.ExtensionDesc, visited map[string]bool) (*FieldRefParams, error) {
+	if visited[message.FullyQualifiedName()] {
+		return nil, nil
+	}
+	visited[message.FullyQualifiedName()] = true
+
+	for i, field := range message.Fields() {
+		var isAnnotatedField bool
+		ok, err := field.Extension(extension, &isAnnotatedField)
+		if err != nil {
+			return nil, newExtensionError(field, extension.Name, err)
+		}
+
+		if ok && isAnnotatedField {
+			fieldName, err := c.FieldName(field)
+			if err != nil {
+				return nil, err
+			}
+			fieldType, err := c.FieldTypeParams(field)
+			if err != nil {
+				return nil, err
+			}
+			return &FieldRefParams{
+				Field: FieldParams{
+					Name:   field.Name().String(),
+					Index:  i,
+					Number: field.Descriptor().GetNumber(),
+					Type:   fieldType,
+					Path: []PathParams{
+						{
+							Name: fieldName,
+							Type: fieldType,
+						},
+					},
+					HasPresence: field.HasPresence(),
+					IsOptional:  field.HasOptionalKeyword(),
+				},
+			}, nil
+		} else if field.Type().IsEmbed() {
+			child, err := c.findAnnotatedField(field.Type().Embed(), extension, visited)
+			if err != nil {
+				return nil, err
+			} else if child == nil {
+				continue
+			}
+
+			// The reference identifies the annotated leaf field, reached through the path from this message
+			fieldName, err := c.FieldName(field)
+			if err != nil {
+				return nil, err
+			}
+			fieldType, err := c.FieldTypeParams(field)
+			if err != nil {
+				return nil, err
+			}
+			child.Field.Path = append([]PathParams{
+				{
+					Name: fieldName,
+					Type: fieldType,
+				},
+			}, child.Field.Path...)
+			return child, nil
+		}
+	}
+	return nil, nil
+}
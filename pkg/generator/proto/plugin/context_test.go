@@ -0,0 +1,364 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"github.com/atomix/codegen/pkg/generator/proto/include"
+	"github.com/atomix/codegen/pkg/generator/template"
+	plugin_go "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	pgs "github.com/lyft/protoc-gen-star"
+	pgsgo "github.com/lyft/protoc-gen-star/lang/go"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"testing"
+)
+
+const testImportPath = "github.com/atomix/codegen/test/v1"
+
+const testProto = `
+syntax = "proto3";
+
+package test.v1;
+
+option go_package = "github.com/atomix/codegen/test/v1;testv1";
+
+import "google/protobuf/timestamp.proto";
+import "gogoproto/gogo.proto";
+
+enum Color {
+  RED = 0;
+}
+
+message Value {}
+
+message Types {
+  double double_field = 1;
+  float float_field = 2;
+  int32 int32_field = 3;
+  int64 int64_field = 4;
+  uint32 uint32_field = 5;
+  uint64 uint64_field = 6;
+  sint32 sint32_field = 7;
+  sint64 sint64_field = 8;
+  fixed32 fixed32_field = 9;
+  fixed64 fixed64_field = 10;
+  sfixed32 sfixed32_field = 11;
+  sfixed64 sfixed64_field = 12;
+  bool bool_field = 13;
+  string string_field = 14;
+  bytes bytes_field = 15;
+  Color enum_field = 16;
+  Value message_field = 17;
+  google.protobuf.Timestamp timestamp_field = 18;
+  repeated int32 repeated_int32_field = 19;
+  repeated Value repeated_message_field = 20;
+  map<int32, string> int32_map = 21;
+  map<int64, string> int64_map = 22;
+  map<uint32, string> uint32_map = 23;
+  map<uint64, string> uint64_map = 24;
+  map<sint32, string> sint32_map = 25;
+  map<sint64, string> sint64_map = 26;
+  map<fixed32, string> fixed32_map = 27;
+  map<fixed64, string> fixed64_map = 28;
+  map<sfixed32, string> sfixed32_map = 29;
+  map<sfixed64, string> sfixed64_map = 30;
+  map<bool, string> bool_map = 31;
+  map<string, bytes> string_map = 32;
+  map<string, Color> enum_map = 33;
+  map<string, Value> message_map = 34;
+  map<uint64, string> cast_key_map = 35 [(gogoproto.castkey) = "ID"];
+  map<string, bytes> cast_value_map = 36 [(gogoproto.castvalue) = "github.com/example/types.Blob"];
+  map<string, bytes> custom_type_map = 37 [(gogoproto.customtype) = "Bytes"];
+}
+
+message Choice {
+  oneof value {
+    string name = 1;
+    int32 id = 2;
+  }
+  bool other = 3;
+}
+`
+
+// typeFlags is the set of type flags checked by the tests
+type typeFlags struct {
+	IsScalar   bool
+	IsMessage  bool
+	IsEnum     bool
+	IsMap      bool
+	IsRepeated bool
+	IsPointer  bool
+	IsBytes    bool
+	IsString   bool
+	IsInt32    bool
+	IsInt64    bool
+	IsUint32   bool
+	IsUint64   bool
+	IsFloat    bool
+	IsDouble   bool
+	IsBool     bool
+	IsCast     bool
+}
+
+func getTypeFlags(p TypeParams) typeFlags {
+	return typeFlags{
+		IsScalar:   p.IsScalar,
+		IsMessage:  p.IsMessage,
+		IsEnum:     p.IsEnum,
+		IsMap:      p.IsMap,
+		IsRepeated: p.IsRepeated,
+		IsPointer:  p.IsPointer,
+		IsBytes:    p.IsBytes,
+		IsString:   p.IsString,
+		IsInt32:    p.IsInt32,
+		IsInt64:    p.IsInt64,
+		IsUint32:   p.IsUint32,
+		IsUint64:   p.IsUint64,
+		IsFloat:    p.IsFloat,
+		IsDouble:   p.IsDouble,
+		IsBool:     p.IsBool,
+		IsCast:     p.IsCast,
+	}
+}
+
+// testQualify qualifies types declared outside the test package with the base name of their package
+func testQualify(qualifier template.GoQualifier) string {
+	importPath, _ := qualifier.GoPackage()
+	if importPath == "" || importPath == testImportPath {
+		return qualifier.GoIdent()
+	}
+	return path.Base(importPath) + "." + qualifier.GoIdent()
+}
+
+// newTestMessage parses the given proto source and returns the context and the named message
+func newTestMessage(t *testing.T, source string, name string) (*Context, pgs.Message) {
+	// Write the test source beside the bundled include files so it can import the gogoproto extensions
+	dir := t.TempDir()
+	if err := include.Write(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "test.proto"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	parser := protoparse.Parser{
+		ImportPaths: []string{dir},
+	}
+	files, err := parser.ParseFiles("test.proto")
+	if err != nil {
+		t.Fatal(err)
+	}
+	request := &plugin_go.CodeGeneratorRequest{
+		FileToGenerate: []string{"test.proto"},
+		ProtoFile:      desc.ToFileDescriptorSet(files...).File,
+	}
+	ast := pgs.ProcessCodeGeneratorRequest(pgs.InitMockDebugger(), request)
+	ctx := newContext(pgsgo.InitContext(pgs.Parameters{}))
+	for _, message := range ast.Targets()["test.proto"].Messages() {
+		if message.Name().String() == name {
+			return ctx, message
+		}
+	}
+	t.Fatalf("message %s not found", name)
+	return nil, nil
+}
+
+func getTestField(t *testing.T, message pgs.Message, name string) pgs.Field {
+	for _, field := range message.Fields() {
+		if field.Name().String() == name {
+			return field
+		}
+	}
+	t.Fatalf("field %s not found", name)
+	return nil
+}
+
+func TestFieldTypeParams(t *testing.T) {
+	ctx, message := newTestMessage(t, testProto, "Types")
+
+	tests := []struct {
+		field  string
+		name   string
+		goType string
+		flags  typeFlags
+	}{
+		{"double_field", "float64", "float64", typeFlags{IsScalar: true, IsDouble: true}},
+		{"float_field", "float32", "float32", typeFlags{IsScalar: true, IsFloat: true}},
+		{"int32_field", "int32", "int32", typeFlags{IsScalar: true, IsInt32: true}},
+		{"int64_field", "int64", "int64", typeFlags{IsScalar: true, IsInt64: true}},
+		{"uint32_field", "uint32", "uint32", typeFlags{IsScalar: true, IsUint32: true}},
+		{"uint64_field", "uint64", "uint64", typeFlags{IsScalar: true, IsUint64: true}},
+		{"sint32_field", "int32", "int32", typeFlags{IsScalar: true, IsInt32: true}},
+		{"sint64_field", "int64", "int64", typeFlags{IsScalar: true, IsInt64: true}},
+		{"fixed32_field", "uint32", "uint32", typeFlags{IsScalar: true, IsUint32: true}},
+		{"fixed64_field", "uint64", "uint64", typeFlags{IsScalar: true, IsUint64: true}},
+		{"sfixed32_field", "int32", "int32", typeFlags{IsScalar: true, IsInt32: true}},
+		{"sfixed64_field", "int64", "int64", typeFlags{IsScalar: true, IsInt64: true}},
+		{"bool_field", "bool", "bool", typeFlags{IsScalar: true, IsBool: true}},
+		{"string_field", "string", "string", typeFlags{IsScalar: true, IsString: true}},
+		{"bytes_field", "[]byte", "[]byte", typeFlags{IsScalar: true, IsBytes: true}},
+		{"enum_field", "Color", "Color", typeFlags{IsEnum: true}},
+		{"message_field", "Value", "*Value", typeFlags{IsMessage: true, IsPointer: true}},
+		{"timestamp_field", "Timestamp", "*types.Timestamp", typeFlags{IsMessage: true, IsPointer: true}},
+		{"repeated_int32_field", "int32", "[]int32", typeFlags{IsScalar: true, IsInt32: true, IsRepeated: true}},
+		{"repeated_message_field", "Value", "[]*Value", typeFlags{IsMessage: true, IsPointer: true, IsRepeated: true}},
+		{"int32_map", "map", "map[int32]string", typeFlags{IsMap: true}},
+		{"sint64_map", "map", "map[int64]string", typeFlags{IsMap: true}},
+		{"enum_map", "map", "map[string]Color", typeFlags{IsMap: true}},
+		{"message_map", "map", "map[string]*Value", typeFlags{IsMap: true}},
+	}
+	for _, test := range tests {
+		t.Run(test.field, func(t *testing.T) {
+			typeParams, err := ctx.FieldTypeParams(getTestField(t, message, test.field))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if typeParams.Name != test.name {
+				t.Errorf("expected Name %q, got %q", test.name, typeParams.Name)
+			}
+			if goType := typeParams.GoType(testQualify); goType != test.goType {
+				t.Errorf("expected GoType %q, got %q", test.goType, goType)
+			}
+			if flags := getTypeFlags(typeParams); flags != test.flags {
+				t.Errorf("expected flags %+v, got %+v", test.flags, flags)
+			}
+		})
+	}
+}
+
+// mapTypeParams is the expected metadata for the key or value type of a map field
+type mapTypeParams struct {
+	name          string
+	goType        string
+	goPackagePath string
+	goPackageName string
+	flags         typeFlags
+}
+
+func TestMapFieldTypeParams(t *testing.T) {
+	ctx, message := newTestMessage(t, testProto, "Types")
+
+	tests := []struct {
+		field     string
+		keyType   mapTypeParams
+		valueType mapTypeParams
+	}{
+		{"int32_map", mapTypeParams{"int32", "int32", "", "", typeFlags{IsScalar: true, IsInt32: true}}, mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}},
+		{"int64_map", mapTypeParams{"int64", "int64", "", "", typeFlags{IsScalar: true, IsInt64: true}}, mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}},
+		{"uint32_map", mapTypeParams{"uint32", "uint32", "", "", typeFlags{IsScalar: true, IsUint32: true}}, mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}},
+		{"uint64_map", mapTypeParams{"uint64", "uint64", "", "", typeFlags{IsScalar: true, IsUint64: true}}, mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}},
+		{"sint32_map", mapTypeParams{"int32", "int32", "", "", typeFlags{IsScalar: true, IsInt32: true}}, mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}},
+		{"sint64_map", mapTypeParams{"int64", "int64", "", "", typeFlags{IsScalar: true, IsInt64: true}}, mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}},
+		{"fixed32_map", mapTypeParams{"uint32", "uint32", "", "", typeFlags{IsScalar: true, IsUint32: true}}, mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}},
+		{"fixed64_map", mapTypeParams{"uint64", "uint64", "", "", typeFlags{IsScalar: true, IsUint64: true}}, mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}},
+		{"sfixed32_map", mapTypeParams{"int32", "int32", "", "", typeFlags{IsScalar: true, IsInt32: true}}, mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}},
+		{"sfixed64_map", mapTypeParams{"int64", "int64", "", "", typeFlags{IsScalar: true, IsInt64: true}}, mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}},
+		{"bool_map", mapTypeParams{"bool", "bool", "", "", typeFlags{IsScalar: true, IsBool: true}}, mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}},
+		{"string_map", mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}, mapTypeParams{"[]byte", "[]byte", "", "", typeFlags{IsScalar: true, IsBytes: true}}},
+		{"enum_map", mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}, mapTypeParams{"Color", "Color", testImportPath, "testv1", typeFlags{IsEnum: true}}},
+		{"message_map", mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}, mapTypeParams{"Value", "*Value", testImportPath, "testv1", typeFlags{IsMessage: true, IsPointer: true}}},
+		{"cast_key_map", mapTypeParams{"ID", "ID", testImportPath, "testv1", typeFlags{IsScalar: true, IsUint64: true, IsCast: true}}, mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}},
+		{"cast_value_map", mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}, mapTypeParams{"github.com/example/types.Blob", "types.Blob", "github.com/example/types", "", typeFlags{IsScalar: true, IsBytes: true, IsCast: true}}},
+		{"custom_type_map", mapTypeParams{"string", "string", "", "", typeFlags{IsScalar: true, IsString: true}}, mapTypeParams{"Bytes", "Bytes", testImportPath, "testv1", typeFlags{IsScalar: true, IsBytes: true}}},
+	}
+	for _, test := range tests {
+		t.Run(test.field, func(t *testing.T) {
+			field := getTestField(t, message, test.field)
+			keyType, err := ctx.FieldKeyTypeParams(field)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkMapTypeParams(t, "key", keyType, test.keyType)
+
+			valueType, err := ctx.FieldValueTypeParams(field)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkMapTypeParams(t, "value", valueType, test.valueType)
+		})
+	}
+}
+
+// checkMapTypeParams compares the key or value type of a map field with the expected metadata
+func checkMapTypeParams(t *testing.T, kind string, typeParams TypeParams, expected mapTypeParams) {
+	if typeParams.Name != expected.name {
+		t.Errorf("expected %s Name %q, got %q", kind, expected.name, typeParams.Name)
+	}
+	if goType := typeParams.GoType(testQualify); goType != expected.goType {
+		t.Errorf("expected %s GoType %q, got %q", kind, expected.goType, goType)
+	}
+	if path, name := typeParams.GoPackage(); path != expected.goPackagePath || name != expected.goPackageName {
+		t.Errorf("expected %s GoPackage (%q, %q), got (%q, %q)", kind, expected.goPackagePath, expected.goPackageName, path, name)
+	}
+	if flags := getTypeFlags(typeParams); flags != expected.flags {
+		t.Errorf("expected %s flags %+v, got %+v", kind, expected.flags, flags)
+	}
+}
+
+func TestTypeParamsGoPackage(t *testing.T) {
+	ctx, message := newTestMessage(t, testProto, "Types")
+
+	tests := []struct {
+		field string
+		path  string
+		name  string
+	}{
+		{"int32_field", "", ""},
+		{"string_field", "", ""},
+		{"bytes_field", "", ""},
+		{"enum_field", testImportPath, "testv1"},
+		{"message_field", testImportPath, "testv1"},
+		{"timestamp_field", "github.com/gogo/protobuf/types", ""},
+	}
+	for _, test := range tests {
+		t.Run(test.field, func(t *testing.T) {
+			typeParams, err := ctx.FieldTypeParams(getTestField(t, message, test.field))
+			if err != nil {
+				t.Fatal(err)
+			}
+			path, name := typeParams.GoPackage()
+			if path != test.path || name != test.name {
+				t.Errorf("expected GoPackage (%q, %q), got (%q, %q)", test.path, test.name, path, name)
+			}
+		})
+	}
+}
+
+func TestMessageParamsOneofs(t *testing.T) {
+	ctx, message := newTestMessage(t, testProto, "Choice")
+	messageParams, err := ctx.MessageParams(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messageParams.Oneofs) != 1 {
+		t.Fatalf("expected 1 oneof, got %d", len(messageParams.Oneofs))
+	}
+	oneof := &messageParams.Oneofs[0]
+
+	for _, field := range messageParams.Fields {
+		if field.Name == "other" {
+			if field.Oneof != nil {
+				t.Errorf("expected field %s not to be in a oneof", field.Name)
+			}
+		} else if field.Oneof != oneof {
+			t.Errorf("expected field %s to be in oneof %s", field.Name, oneof.Name)
+		}
+	}
+
+	if len(oneof.Fields) != 2 {
+		t.Fatalf("expected 2 oneof fields, got %d", len(oneof.Fields))
+	}
+	for i, name := range []string{"name", "id"} {
+		field := oneof.Fields[i]
+		if field.Name != name {
+			t.Errorf("expected oneof field %d to be %s, got %s", i, name, field.Name)
+		}
+		if field.Oneof != oneof {
+			t.Errorf("expected oneof field %s to refer to oneof %s", field.Name, oneof.Name)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"fmt"
+	codegenv1 "github.com/atomix/codegen/api/atomix/codegen/v1"
+	"github.com/gogo/protobuf/gogoproto"
+	gogoprotobuf "github.com/gogo/protobuf/proto"
+	gogodescriptor "github.com/gogo/protobuf/protoc-gen-gogo/descriptor"
+	"github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	pgs "github.com/lyft/protoc-gen-star"
+)
+
+// getEmbed gets the embed extension from the given field
+func getEmbed(field pgs.Field) (*bool, error) {
+	var embed bool
+	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Embed), &embed)
+	if err != nil {
+		return nil, newExtensionError(field, gogoproto.E_Embed.Name, err)
+	} else if !ok {
+		return nil, nil
+	}
+	return &embed, nil
+}
+
+// getCastType gets the casttype extension from the given field
+func getCastType(field pgs.Field) (*string, error) {
+	var castType string
+	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Casttype), &castType)
+	if err != nil {
+		return nil, newExtensionError(field, gogoproto.E_Casttype.Name, err)
+	} else if !ok {
+		return nil, nil
+	}
+	return &castType, nil
+}
+
+// getCastKey gets the castkey extension from the given field
+func getCastKey(field pgs.Field) (*string, error) {
+	var castKey string
+	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Castkey), &castKey)
+	if err != nil {
+		return nil, newExtensionError(field, gogoproto.E_Castkey.Name, err)
+	} else if !ok {
+		return nil, nil
+	}
+	return &castKey, nil
+}
+
+// getCastValue gets the castvalue extension from the given field
+func getCastValue(field pgs.Field) (*string, error) {
+	var castValue string
+	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Castvalue), &castValue)
+	if err != nil {
+		return nil, newExtensionError(field, gogoproto.E_Castvalue.Name, err)
+	} else if !ok {
+		return nil, nil
+	}
+	return &castValue, nil
+}
+
+// getCustomName gets the customname extension from the given field
+func getCustomName(field pgs.Field) (*string, error) {
+	var customName string
+	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Customname), &customName)
+	if err != nil {
+		return nil, newExtensionError(field, gogoproto.E_Customname.Name, err)
+	} else if !ok {
+		return nil, nil
+	}
+	return &customName, nil
+}
+
+// getCustomType gets the customtype extension from the given field
+func getCustomType(field pgs.Field) (*string, error) {
+	var customType string
+	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Customtype), &customType)
+	if err != nil {
+		return nil, newExtensionError(field, gogoproto.E_Customtype.Name, err)
+	} else if !ok {
+		return nil, nil
+	}
+	return &customType, nil
+}
+
+// getNullable gets the nullable extension from the given field
+func getNullable(field pgs.Field) (*bool, error) {
+	var nullable bool
+	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Nullable), &nullable)
+	if err != nil {
+		return nil, newExtensionError(field, gogoproto.E_Nullable.Name, err)
+	} else if !ok {
+		return nil, nil
+	}
+	return &nullable, nil
+}
+
+// getStdtime gets the stdtime extension from the given field
+func getStdtime(field pgs.Field) (*bool, error) {
+	var stdtime bool
+	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Stdtime), &stdtime)
+	if err != nil {
+		return nil, newExtensionError(field, gogoproto.E_Stdtime.Name, err)
+	} else if !ok {
+		return nil, nil
+	}
+	return &stdtime, nil
+}
+
+// getStdduration gets the stdduration extension from the given field
+func getStdduration(field pgs.Field) (*bool, error) {
+	var stdduration bool
+	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Stdduration), &stdduration)
+	if err != nil {
+		return nil, newExtensionError(field, gogoproto.E_Stdduration.Name, err)
+	} else if !ok {
+		return nil, nil
+	}
+	return &stdduration, nil
+}
+
+// getWktpointer gets the wktpointer extension from the given field
+func getWktpointer(field pgs.Field) (*bool, error) {
+	var wktpointer bool
+	ok, err := field.Extension(getExtensionDesc(gogoproto.E_Wktpointer), &wktpointer)
+	if err != nil {
+		return nil, newExtensionError(field, gogoproto.E_Wktpointer.Name, err)
+	} else if !ok {
+		return nil, nil
+	}
+	return &wktpointer, nil
+}
+
+// getPrimitiveType gets the primitive_type extension from the given service
+func getPrimitiveType(service pgs.Service) (*string, error) {
+	var primitiveType string
+	ok, err := service.Extension(getExtensionDesc(codegenv1.E_PrimitiveType), &primitiveType)
+	if err != nil {
+		return nil, newExtensionError(service, codegenv1.E_PrimitiveType.Name, err)
+	} else if !ok {
+		return nil, nil
+	}
+	return &primitiveType, nil
+}
+
+// getOperationType gets the operation_type extension from the given method
+func getOperationType(method pgs.Method) (*codegenv1.OperationType, error) {
+	var operationType codegenv1.OperationType
+	ok, err := method.Extension(getExtensionDesc(codegenv1.E_OperationType), &operationType)
+	if err != nil {
+		return nil, newExtensionError(method, codegenv1.E_OperationType.Name, err)
+	} else if !ok {
+		return nil, nil
+	}
+	return &operationType, nil
+}
+
+// getAsync gets the async extension from the given method
+func getAsync(method pgs.Method) (*bool, error) {
+	var async bool
+	ok, err := method.Extension(getExtensionDesc(codegenv1.E_Async), &async)
+	if err != nil {
+		return nil, newExtensionError(method, codegenv1.E_Async.Name, err)
+	} else if !ok {
+		return nil, nil
+	}
+	return &async, nil
+}
+
+func getExtensionDesc(extension *gogoprotobuf.ExtensionDesc) *proto.ExtensionDesc {
+	return &proto.ExtensionDesc{
+		ExtendedType:  getExtendedType(extension),
+		ExtensionType: extension.ExtensionType,
+		Field:         extension.Field,
+		Name:          extension.Name,
+		Tag:           extension.Tag,
+		Filename:      extension.Filename,
+	}
+}
+
+func getExtendedType(extension *gogoprotobuf.ExtensionDesc) proto.Message {
+	switch extension.ExtendedType.(type) {
+	case *gogodescriptor.ServiceOptions:
+		return (*descriptor.ServiceOptions)(nil)
+	case *gogodescriptor.MethodOptions:
+		return (*descriptor.MethodOptions)(nil)
+	default:
+		return (*descriptor.FieldOptions)(nil)
+	}
+}
+
+// newExtensionError wraps an extension decoding error with the extension and entity names
+func newExtensionError(entity pgs.Entity, extension string, err error) error {
+	return fmt.Errorf("failed to decode extension %s on %s: %w", extension, entity.FullyQualifiedName(), err)
+}
@@ -0,0 +1,246 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin_test
+
+import (
+	"flag"
+	"github.com/atomix/codegen/pkg/generator/proto"
+	"github.com/atomix/codegen/pkg/generator/proto/include"
+	"github.com/atomix/codegen/pkg/generator/proto/plugin"
+	"github.com/atomix/codegen/pkg/generator/template"
+	"github.com/bmatcuk/doublestar/v4"
+	plugin_go "github.com/golang/protobuf/protoc-gen-go/plugin"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/pmezard/go-difflib/difflib"
+	"go/parser"
+	"go/token"
+	"gopkg.in/yaml.v3"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files with the generated output")
+
+const (
+	testdataDir = "testdata"
+	configFile  = "golden.yaml"
+	goldenDir   = "golden"
+)
+
+// goldenConfig is the golden test configuration
+type goldenConfig struct {
+	Input       goldenInputConfig      `yaml:"input,omitempty"`
+	Templates   []proto.TemplateConfig `yaml:"templates,omitempty"`
+	Values      map[string]interface{} `yaml:"values,omitempty"`
+	Boilerplate string                 `yaml:"boilerplate,omitempty"`
+}
+
+// goldenInputConfig is the fixture input configuration
+type goldenInputConfig struct {
+	Path    string   `yaml:"path,omitempty"`
+	Files   []string `yaml:"files,omitempty"`
+	Include []string `yaml:"include,omitempty"`
+}
+
+// TestGolden renders every template in testdata/golden.yaml against the fixture protos and compares
+// the output with the golden files. Run with -update to rewrite the golden files.
+func TestGolden(t *testing.T) {
+	bytes, err := ioutil.ReadFile(filepath.Join(testdataDir, configFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var config goldenConfig
+	if err := yaml.Unmarshal(bytes, &config); err != nil {
+		t.Fatal(err)
+	}
+
+	includeDir, err := include.TempDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(includeDir)
+
+	request := newGoldenRequest(t, config.Input, includeDir)
+
+	var boilerplate string
+	if config.Boilerplate != "" {
+		boilerplate, err = template.ReadBoilerplate(filepath.Join(testdataDir, config.Boilerplate))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tpl := range config.Templates {
+		tpl := tpl
+		t.Run(tpl.Name, func(t *testing.T) {
+			tpl.Path = filepath.Join(testdataDir, tpl.Path)
+			parameter, err := proto.GetParameter([]proto.TemplateConfig{tpl}, config.Values, boilerplate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			request.Parameter = &parameter
+
+			response, err := plugin.Run(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.Error != nil {
+				t.Fatal(response.GetError())
+			}
+
+			for _, file := range response.File {
+				checkGoImports(t, file.GetName(), file.GetContent())
+			}
+
+			dir := filepath.Join(testdataDir, goldenDir, tpl.Name)
+			if *update {
+				writeGolden(t, dir, response.File)
+			} else {
+				compareGolden(t, dir, response.File)
+			}
+		})
+	}
+}
+
+func newGoldenRequest(t *testing.T, config goldenInputConfig, includeDir string) *plugin_go.CodeGeneratorRequest {
+	inputPath := filepath.Join(testdataDir, config.Path)
+	importPaths := []string{inputPath}
+	for _, importPath := range config.Include {
+		importPaths = append(importPaths, filepath.Join(testdataDir, importPath))
+	}
+	importPaths = append(importPaths, includeDir)
+
+	var fileNames []string
+	for _, pattern := range config.Files {
+		matches, err := doublestar.Glob(os.DirFS(inputPath), pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fileNames = append(fileNames, matches...)
+	}
+	sort.Strings(fileNames)
+
+	parser := protoparse.Parser{
+		ImportPaths:           importPaths,
+		IncludeSourceCodeInfo: true,
+	}
+	files, err := parser.ParseFiles(fileNames...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &plugin_go.CodeGeneratorRequest{
+		FileToGenerate: fileNames,
+		ProtoFile:      desc.ToFileDescriptorSet(files...).File,
+	}
+}
+
+// checkGoImports fails the test if a generated Go file does not parse or imports a package or name more than once
+func checkGoImports(t *testing.T, name string, content string) {
+	if filepath.Ext(name) != ".go" {
+		return
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), name, content, parser.ImportsOnly)
+	if err != nil {
+		t.Errorf("%s: %s", name, err)
+		return
+	}
+	paths := make(map[string]bool)
+	names := make(map[string]bool)
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+		if paths[importPath] {
+			t.Errorf("%s: %s is imported more than once", name, importPath)
+		}
+		paths[importPath] = true
+		if spec.Name != nil && spec.Name.Name != "_" {
+			if names[spec.Name.Name] {
+				t.Errorf("%s: %s is declared more than once", name, spec.Name.Name)
+			}
+			names[spec.Name.Name] = true
+		}
+	}
+}
+
+func writeGolden(t *testing.T, dir string, files []*plugin_go.CodeGeneratorResponse_File) {
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		path := filepath.Join(dir, file.GetName())
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(file.GetContent()), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func compareGolden(t *testing.T, dir string, files []*plugin_go.CodeGeneratorResponse_File) {
+	expected := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		bytes, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		expected[filepath.ToSlash(name)] = string(bytes)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+
+	actual := make(map[string]string)
+	for _, file := range files {
+		actual[filepath.ToSlash(file.GetName())] = file.GetContent()
+	}
+
+	names := make(map[string]bool)
+	for name := range expected {
+		names[name] = true
+	}
+	for name := range actual {
+		names[name] = true
+	}
+	var sortedNames []string
+	for name := range names {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	for _, name := range sortedNames {
+		if expected[name] == actual[name] {
+			continue
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(expected[name]),
+			B:        difflib.SplitLines(actual[name]),
+			FromFile: filepath.Join(dir, name),
+			ToFile:   name,
+			Context:  3,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Errorf("%s does not match the golden file; run with -update to update the golden files\n%s", name, diff)
+	}
+}
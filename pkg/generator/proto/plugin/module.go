@@ -0,0 +1,368 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	codegenv1 "github.com/atomix/codegen/api/atomix/codegen/v1"
+	"github.com/atomix/codegen/pkg/generator/template"
+	pgs "github.com/lyft/protoc-gen-star"
+	pgsgo "github.com/lyft/protoc-gen-star/lang/go"
+	"path/filepath"
+	"sort"
+	"strings"
+	gotemplate "text/template"
+)
+
+const moduleName = "primitive"
+
+// NewModule creates a new proto module
+func NewModule() pgs.Module {
+	return &Module{
+		ModuleBase: &pgs.ModuleBase{},
+	}
+}
+
+// Module is the code generation module
+type Module struct {
+	*pgs.ModuleBase
+	ctx *Context
+}
+
+// Name returns the module name
+func (m *Module) Name() string {
+	return moduleName
+}
+
+// InitContext initializes the module context
+func (m *Module) InitContext(c pgs.BuildContext) {
+	m.ModuleBase.InitContext(c)
+	m.ctx = newContext(pgsgo.InitContext(c.Parameters()))
+	for key, value := range c.Parameters() {
+		c.Logf("%s=%s", key, value)
+	}
+}
+
+// Execute executes the code generator
+func (m *Module) Execute(targets map[string]pgs.File, packages map[string]pgs.Package) []pgs.Artifact {
+	templates, err := m.ctx.Templates()
+	m.CheckErr(err, "failed to read templates")
+	for _, spec := range templates {
+		m.Push(spec.Name)
+		m.execute(spec, targets)
+		m.Pop()
+	}
+	return m.Artifacts()
+}
+
+func (m *Module) execute(spec TemplateSpec, targets map[string]pgs.File) {
+	switch spec.Type {
+	case serviceTemplateType:
+		for _, target := range targets {
+			m.Push(target.InputPath().String())
+			for _, service := range target.Services() {
+				m.Push(service.Name().String())
+				m.CheckErr(m.generateService(spec, service), "failed to generate service")
+				m.Pop()
+			}
+			m.Pop()
+		}
+	case fileTemplateType:
+		for _, target := range targets {
+			m.Push(target.InputPath().String())
+			m.CheckErr(m.generateFile(spec, target), "failed to generate file")
+			m.Pop()
+		}
+	case messageTemplateType:
+		for _, target := range targets {
+			m.Push(target.InputPath().String())
+			for _, message := range target.AllMessages() {
+				if !message.IsMapEntry() {
+					m.Push(message.Name().String())
+					m.CheckErr(m.generateMessage(spec, message), "failed to generate message")
+					m.Pop()
+				}
+			}
+			m.Pop()
+		}
+	case enumTemplateType:
+		for _, target := range targets {
+			m.Push(target.InputPath().String())
+			for _, enum := range target.AllEnums() {
+				m.Push(enum.Name().String())
+				m.CheckErr(m.generateEnum(spec, enum), "failed to generate enum")
+				m.Pop()
+			}
+			m.Pop()
+		}
+	case requestTemplateType:
+		m.CheckErr(m.generateRequest(spec, targets), "failed to generate request")
+	default:
+		m.Failf("unknown template type '%s'", spec.Type)
+	}
+}
+
+func (m *Module) generateService(spec TemplateSpec, service pgs.Service) error {
+	descriptor, err := m.getDescriptor(service)
+	if err != nil {
+		return err
+	}
+	return m.generate(spec, service.Name().String(), Params{
+		Service: descriptor,
+	})
+}
+
+func (m *Module) generateFile(spec TemplateSpec, file pgs.File) error {
+	descriptor, err := m.getFileDescriptor(file)
+	if err != nil {
+		return err
+	}
+	return m.generate(spec, file.InputPath().String(), Params{
+		File: descriptor,
+	})
+}
+
+func (m *Module) generateMessage(spec TemplateSpec, message pgs.Message) error {
+	descriptor, err := m.ctx.MessageParams(message)
+	if err != nil {
+		return err
+	}
+	return m.generate(spec, message.FullyQualifiedName(), Params{
+		Message: descriptor,
+	})
+}
+
+func (m *Module) generateEnum(spec TemplateSpec, enum pgs.Enum) error {
+	return m.generate(spec, enum.FullyQualifiedName(), Params{
+		Enum: m.ctx.EnumParams(enum),
+	})
+}
+
+func (m *Module) generateRequest(spec TemplateSpec, targets map[string]pgs.File) error {
+	names := make([]string, 0, len(targets))
+	for name := range targets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	files := make([]FileDescriptorParams, 0, len(names))
+	for _, name := range names {
+		descriptor, err := m.getFileDescriptor(targets[name])
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		files = append(files, descriptor)
+	}
+	return m.generate(spec, "request", Params{
+		Files: files,
+	})
+}
+
+func (m *Module) generate(spec TemplateSpec, name string, params Params) error {
+	values, err := m.ctx.Values()
+	if err != nil {
+		return err
+	}
+	params.Values = values
+
+	boilerplate, err := m.ctx.Boilerplate()
+	if err != nil {
+		return err
+	}
+
+	outputPath, err := m.ctx.OutputPath(spec, params)
+	if err != nil {
+		return err
+	}
+	m.Logf("%s => %s", name, outputPath)
+
+	// Templates may emit additional files by rendering a named template to another path with
+	// the "emit" function, e.g. {{ emit (printf "%s_test.go" .Service.Name) "test" . }}
+	var emitted []string
+	outputs := make(map[string]string)
+	outputImports := make(map[string]*template.FileImports)
+	imports := template.NewImports()
+	tpl := template.New(filepath.Base(spec.Path)).Funcs(imports.Funcs())
+	tpl = tpl.Funcs(gotemplate.FuncMap{
+		"emit": func(path string, name string, data interface{}) (string, error) {
+			if _, ok := outputs[path]; ok || path == outputPath {
+				return "", fmt.Errorf("output %s is emitted more than once", path)
+			}
+			var buf bytes.Buffer
+			imports.Push()
+			err := tpl.ExecuteTemplate(&buf, name, data)
+			outputImports[path] = imports.Pop()
+			if err != nil {
+				return "", err
+			}
+			emitted = append(emitted, path)
+			outputs[path] = buf.String()
+			return "", nil
+		},
+	})
+
+	if spec.Content != "" {
+		tpl, err = tpl.Parse(spec.Content)
+	} else {
+		tpl, err = tpl.ParseFiles(spec.Path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse template %s: %w", spec.Path, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, params); err != nil {
+		return fmt.Errorf("failed to render template %s: %w", spec.Path, err)
+	}
+	outputImports[outputPath] = imports.Pop()
+
+	// Templates that only emit other files may render an empty primary output, which is skipped
+	if strings.TrimSpace(buf.String()) != "" {
+		if err := m.write(spec, outputPath, boilerplate, buf.String(), outputImports[outputPath]); err != nil {
+			return err
+		}
+	}
+	for _, path := range emitted {
+		m.Logf("%s => %s", name, path)
+		if err := m.write(spec, path, boilerplate, outputs[path], outputImports[path]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write adds the header and imports to the output rendered by the given template and formats it before writing it to the path
+func (m *Module) write(spec TemplateSpec, path string, boilerplate string, output string, imports *template.FileImports) error {
+	content, err := imports.Apply(path, []byte(template.Header(path, boilerplate)+output))
+	if err != nil {
+		return fmt.Errorf("template %s: %w", spec.Path, err)
+	}
+	content, err = template.Format(path, content)
+	if err != nil {
+		return fmt.Errorf("template %s: %w", spec.Path, err)
+	}
+	m.OverwriteGeneratorFile(path, string(content))
+	return nil
+}
+
+func (m *Module) getFileDescriptor(file pgs.File) (FileDescriptorParams, error) {
+	services := make([]ServiceParams, 0, len(file.Services()))
+	for _, service := range file.Services() {
+		descriptor, err := m.getDescriptor(service)
+		if err != nil {
+			return FileDescriptorParams{}, err
+		}
+		services = append(services, descriptor)
+	}
+
+	messages := make([]MessageParams, 0, len(file.AllMessages()))
+	for _, message := range file.AllMessages() {
+		if !message.IsMapEntry() {
+			descriptor, err := m.ctx.MessageParams(message)
+			if err != nil {
+				return FileDescriptorParams{}, fmt.Errorf("message %s: %w", message.Name(), err)
+			}
+			messages = append(messages, descriptor)
+		}
+	}
+
+	enums := make([]EnumParams, 0, len(file.AllEnums()))
+	for _, enum := range file.AllEnums() {
+		enums = append(enums, m.ctx.EnumParams(enum))
+	}
+
+	return FileDescriptorParams{
+		EntityParams: m.ctx.EntityParams(file),
+		Services:     services,
+		Messages:     messages,
+		Enums:        enums,
+	}, nil
+}
+
+func (m *Module) getDescriptor(service pgs.Service) (ServiceParams, error) {
+	// Iterate through the methods on the service and construct method metadata for the template.
+	methods := make([]MethodParams, 0, len(service.Methods()))
+	for i, method := range service.Methods() {
+		methodParams, err := m.getMethodDescriptor(method)
+		if err != nil {
+			return ServiceParams{}, fmt.Errorf("method %s: %w", method.Name(), err)
+		}
+		methodParams.Index = i
+		methods = append(methods, methodParams)
+	}
+
+	var primitiveType string
+	if value, err := getPrimitiveType(service); err != nil {
+		return ServiceParams{}, err
+	} else if value != nil {
+		primitiveType = *value
+	}
+
+	return ServiceParams{
+		EntityParams:  m.ctx.EntityParams(service),
+		Name:          pgsgo.PGGUpperCamelCase(service.Name()).String(),
+		Comment:       m.ctx.Comment(service),
+		PrimitiveType: primitiveType,
+		Methods:       methods,
+	}, nil
+}
+
+func (m *Module) getMethodDescriptor(method pgs.Method) (MethodParams, error) {
+	requestMessage, err := m.ctx.MessageParams(method.Input())
+	if err != nil {
+		return MethodParams{}, err
+	}
+	requestHeaders, err := m.ctx.HeadersField(method.Input())
+	if err != nil {
+		return MethodParams{}, err
+	}
+	requestPartitionKey, err := m.ctx.PartitionKeyField(method.Input())
+	if err != nil {
+		return MethodParams{}, err
+	}
+	requestParams := RequestParams{
+		MessageParams: requestMessage,
+		IsUnary:       !method.ClientStreaming(),
+		IsStream:      method.ClientStreaming(),
+		Headers:       requestHeaders,
+		PartitionKey:  requestPartitionKey,
+	}
+
+	// Generate output metadata from the output type.
+	responseMessage, err := m.ctx.MessageParams(method.Output())
+	if err != nil {
+		return MethodParams{}, err
+	}
+	responseHeaders, err := m.ctx.HeadersField(method.Output())
+	if err != nil {
+		return MethodParams{}, err
+	}
+	responseParams := ResponseParams{
+		MessageParams: responseMessage,
+		IsUnary:       !method.ServerStreaming(),
+		IsStream:      method.ServerStreaming(),
+		Headers:       responseHeaders,
+	}
+
+	operationType, err := getOperationType(method)
+	if err != nil {
+		return MethodParams{}, err
+	}
+	async, err := getAsync(method)
+	if err != nil {
+		return MethodParams{}, err
+	}
+
+	return MethodParams{
+		Name:      method.Name().UpperCamelCase().String(),
+		Comment:   m.ctx.Comment(method),
+		IsCommand: operationType != nil && *operationType == codegenv1.OperationType_COMMAND,
+		IsQuery:   operationType != nil && *operationType == codegenv1.OperationType_QUERY,
+		IsAsync:   async != nil && *async,
+		Request:   requestParams,
+		Response:  responseParams,
+	}, nil
+}
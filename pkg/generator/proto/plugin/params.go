@@ -0,0 +1,221 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"fmt"
+	"github.com/atomix/codegen/pkg/generator/template"
+)
+
+// Params is the parameters for the code generator
+type Params struct {
+	Files   []FileDescriptorParams
+	File    FileDescriptorParams
+	Service ServiceParams
+	Message MessageParams
+	Enum    EnumParams
+	Values  map[string]any
+}
+
+type EntityParams struct {
+	File    FileParams
+	Package PackageParams
+}
+
+// FileParams is the Protobuf file parameters
+type FileParams struct {
+	Name     string
+	BaseName string
+	Path     string
+}
+
+// PackageParams is the package for a code file
+type PackageParams struct {
+	Name string
+	Path string
+}
+
+// TypeParams is the metadata for a store type
+type TypeParams struct {
+	EntityParams
+	Name         string
+	FullName     string
+	GoName       string
+	GoImportPath string
+	IsPointer    bool
+	IsScalar     bool
+	IsCast       bool
+	IsMessage    bool
+	IsMap        bool
+	IsRepeated   bool
+	IsEnum       bool
+	IsEnumValue  bool
+	IsBytes      bool
+	IsString     bool
+	IsInt32      bool
+	IsInt64      bool
+	IsUint32     bool
+	IsUint64     bool
+	IsFloat      bool
+	IsDouble     bool
+	IsBool       bool
+	IsWellKnown  bool
+	KeyType      *TypeParams
+	ValueType    *TypeParams
+	Values       []TypeParams
+	WellKnown    *WellKnownTypeParams
+}
+
+// GoPackage returns the import path and name of the Go package declaring the type, or an empty path
+// if the type does not need to be qualified, e.g. scalars and well-known types mapped to builtin types.
+// The name is only known for types declared in the Go package of their Protobuf package, and is empty
+// for types mapped to other Go packages, e.g. well-known types
+func (p TypeParams) GoPackage() (string, string) {
+	switch p.GoImportPath {
+	case "":
+		return "", ""
+	case p.Package.Path:
+		return p.GoImportPath, p.Package.Name
+	default:
+		return p.GoImportPath, ""
+	}
+}
+
+// GoIdent returns the unqualified name of the Go type
+func (p TypeParams) GoIdent() string {
+	return p.GoName
+}
+
+// GoType returns the Go type expression for the type, qualifying types declared in other packages
+func (p TypeParams) GoType(qualify func(template.GoQualifier) string) string {
+	switch {
+	case p.IsMap && p.KeyType != nil && p.ValueType != nil:
+		return fmt.Sprintf("map[%s]%s", p.KeyType.GoType(qualify), p.ValueType.GoType(qualify))
+	case p.IsRepeated:
+		elem := p
+		elem.IsRepeated = false
+		return "[]" + elem.GoType(qualify)
+	case p.IsPointer:
+		return "*" + qualify(p)
+	default:
+		return qualify(p)
+	}
+}
+
+// WellKnownTypeParams is the metadata for a google.protobuf well-known type
+type WellKnownTypeParams struct {
+	Name         string
+	GoType       string
+	GoImportPath string
+	IsAny        bool
+	IsDuration   bool
+	IsEmpty      bool
+	IsStruct     bool
+	IsTimestamp  bool
+	IsValue      bool
+	IsListValue  bool
+	IsWrapper    bool
+	IsStdType    bool
+}
+
+// FileDescriptorParams is the metadata for a Protobuf file
+type FileDescriptorParams struct {
+	EntityParams
+	Services []ServiceParams
+	Messages []MessageParams
+	Enums    []EnumParams
+}
+
+// ServiceParams is the metadata for a service
+type ServiceParams struct {
+	EntityParams
+	Name          string
+	Comment       string
+	PrimitiveType string
+	Methods       []MethodParams
+}
+
+// FieldRefParams is metadata for a field reference. The field describes the referenced field, and its path
+// leads to the field through any embedded messages
+type FieldRefParams struct {
+	Field FieldParams
+}
+
+// FieldParams is metadata for a field
+type FieldParams struct {
+	Name        string
+	Index       int
+	Number      int32
+	Type        TypeParams
+	Path        []PathParams
+	Message     *MessageParams
+	Oneof       *OneofParams
+	HasPresence bool
+	IsOptional  bool
+}
+
+// PathParams is metadata for a field path
+type PathParams struct {
+	Name string
+	Type TypeParams
+}
+
+// MethodParams is the metadata for a primitive method
+type MethodParams struct {
+	Name      string
+	Index     int
+	Comment   string
+	IsCommand bool
+	IsQuery   bool
+	IsAsync   bool
+	Request   RequestParams
+	Response  ResponseParams
+}
+
+// MessageParams is the metadata for a message
+type MessageParams struct {
+	Type    TypeParams
+	Comment string
+	Fields  []FieldParams
+	Oneofs  []OneofParams
+}
+
+// OneofParams is the metadata for a oneof
+type OneofParams struct {
+	Name   string
+	Index  int
+	Fields []FieldParams
+}
+
+// EnumParams is the metadata for an enum
+type EnumParams struct {
+	Type    TypeParams
+	Comment string
+	Values  []EnumValueParams
+}
+
+// EnumValueParams is the metadata for an enum value
+type EnumValueParams struct {
+	Type    TypeParams
+	Comment string
+	Number  int32
+}
+
+// RequestParams is the type metadata for a message
+type RequestParams struct {
+	MessageParams
+	IsUnary      bool
+	IsStream     bool
+	Headers      *FieldRefParams
+	PartitionKey *FieldRefParams
+}
+
+// ResponseParams is the type metadata for a message
+type ResponseParams struct {
+	MessageParams
+	IsUnary  bool
+	IsStream bool
+	Headers  *FieldRefParams
+}
@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	plugin_go "github.com/golang/protobuf/protoc-gen-go/plugin"
+	pgs "github.com/lyft/protoc-gen-star"
+	"io/ioutil"
+	"strings"
+)
+
+// Run executes the module in-process against the given code generator request
+func Run(request *plugin_go.CodeGeneratorRequest) (response *plugin_go.CodeGeneratorResponse, err error) {
+	debugger := pgs.InitMockDebugger()
+	defer func() {
+		if r := recover(); r != nil {
+			response = nil
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	params := pgs.ParseParameters(request.GetParameter())
+	ast := pgs.ProcessCodeGeneratorRequest(debugger, request)
+	module := NewModule()
+	module.InitContext(pgs.Context(debugger, params, params.OutputPath()).Push(module.Name()))
+	artifacts := module.Execute(ast.Targets(), ast.Packages())
+	if debugger.Failed() || debugger.Exited() {
+		output, _ := ioutil.ReadAll(debugger.Output())
+		return nil, errors.New(strings.TrimSpace(string(output)))
+	}
+
+	response = &plugin_go.CodeGeneratorResponse{}
+	var messages []string
+	for _, artifact := range artifacts {
+		switch a := artifact.(type) {
+		case pgs.GeneratorArtifact:
+			file, err := a.ProtoFile()
+			if err != nil {
+				return nil, err
+			}
+			response.File = append(response.File, file)
+		case pgs.GeneratorError:
+			messages = append(messages, a.Message)
+		}
+	}
+	if len(messages) > 0 {
+		message := strings.Join(messages, "; ")
+		response.Error = &message
+	}
+	return response, nil
+}
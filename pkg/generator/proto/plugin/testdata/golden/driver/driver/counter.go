@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by atomix-codegen dev. DO NOT EDIT.
+
+package driver
+
+import (
+	"context"
+
+	counterv1 "github.com/atomix/codegen/testdata/counter/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	// atomix-codegen:begin-user-code imports
+	// atomix-codegen:end-user-code imports
+)
+
+func init() {
+	registerPrimitive(func(server *grpc.Server, conn *Conn) {
+		counterv1.RegisterCounterServer(server, newCounterServer(conn))
+	})
+}
+
+// newCounterServer creates a new Counter server for the given connection
+func newCounterServer(conn *Conn) counterv1.CounterServer {
+	return &counterServer{
+		conn: conn,
+	}
+}
+
+// counterServer implements the Counter service
+type counterServer struct {
+	conn *Conn
+	// atomix-codegen:begin-user-code counterServer
+	// atomix-codegen:end-user-code counterServer
+}
+
+func (s *counterServer) Increment(ctx context.Context, request *counterv1.IncrementRequest) (*counterv1.IncrementResponse, error) {
+	// atomix-codegen:begin-user-code Increment
+	// TODO: implement Increment
+	return nil, status.Error(codes.Unimplemented, "Increment not implemented")
+	// atomix-codegen:end-user-code Increment
+}
+
+func (s *counterServer) Get(ctx context.Context, request *counterv1.GetRequest) (*counterv1.GetResponse, error) {
+	// atomix-codegen:begin-user-code Get
+	// TODO: implement Get
+	return nil, status.Error(codes.Unimplemented, "Get not implemented")
+	// atomix-codegen:end-user-code Get
+}
+
+func (s *counterServer) Watch(request *counterv1.WatchRequest, stream counterv1.Counter_WatchServer) error {
+	// atomix-codegen:begin-user-code Watch
+	// TODO: implement Watch
+	return status.Error(codes.Unimplemented, "Watch not implemented")
+	// atomix-codegen:end-user-code Watch
+}
+
+func (s *counterServer) Batch(stream counterv1.Counter_BatchServer) error {
+	// atomix-codegen:begin-user-code Batch
+	// TODO: implement Batch
+	return status.Error(codes.Unimplemented, "Batch not implemented")
+	// atomix-codegen:end-user-code Batch
+}
+
+func (s *counterServer) Sync(stream counterv1.Counter_SyncServer) error {
+	// atomix-codegen:begin-user-code Sync
+	// TODO: implement Sync
+	return status.Error(codes.Unimplemented, "Sync not implemented")
+	// atomix-codegen:end-user-code Sync
+}
+
+var _ counterv1.CounterServer = (*counterServer)(nil)
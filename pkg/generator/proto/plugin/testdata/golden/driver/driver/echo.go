@@ -0,0 +1,47 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by atomix-codegen dev. DO NOT EDIT.
+
+package driver
+
+import (
+	"context"
+
+	"github.com/atomix/codegen/testdata/echo/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	// atomix-codegen:begin-user-code imports
+	// atomix-codegen:end-user-code imports
+)
+
+func init() {
+	registerPrimitive(func(server *grpc.Server, conn *Conn) {
+		v1.RegisterEchoServer(server, newEchoServer(conn))
+	})
+}
+
+// newEchoServer creates a new Echo server for the given connection
+func newEchoServer(conn *Conn) v1.EchoServer {
+	return &echoServer{
+		conn: conn,
+	}
+}
+
+// echoServer implements the Echo service
+type echoServer struct {
+	conn *Conn
+	// atomix-codegen:begin-user-code echoServer
+	// atomix-codegen:end-user-code echoServer
+}
+
+func (s *echoServer) Echo(ctx context.Context, request *v1.EchoRequest) (*v1.EchoResponse, error) {
+	// atomix-codegen:begin-user-code Echo
+	// TODO: implement Echo
+	return nil, status.Error(codes.Unimplemented, "Echo not implemented")
+	// atomix-codegen:end-user-code Echo
+}
+
+var _ v1.EchoServer = (*echoServer)(nil)
@@ -0,0 +1,54 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by atomix-codegen dev. DO NOT EDIT.
+
+package driver
+
+import (
+	"context"
+
+	mapv1 "github.com/atomix/codegen/testdata/map/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	// atomix-codegen:begin-user-code imports
+	// atomix-codegen:end-user-code imports
+)
+
+func init() {
+	registerPrimitive(func(server *grpc.Server, conn *Conn) {
+		mapv1.RegisterMapServer(server, newMapServer(conn))
+	})
+}
+
+// newMapServer creates a new Map server for the given connection
+func newMapServer(conn *Conn) mapv1.MapServer {
+	return &mapServer{
+		conn: conn,
+	}
+}
+
+// mapServer implements the Map service
+type mapServer struct {
+	conn *Conn
+	// atomix-codegen:begin-user-code mapServer
+	// atomix-codegen:end-user-code mapServer
+}
+
+func (s *mapServer) Put(ctx context.Context, request *mapv1.PutRequest) (*mapv1.PutResponse, error) {
+	// atomix-codegen:begin-user-code Put
+	// TODO: implement Put
+	return nil, status.Error(codes.Unimplemented, "Put not implemented")
+	// atomix-codegen:end-user-code Put
+}
+
+func (s *mapServer) Get(ctx context.Context, request *mapv1.GetRequest) (*mapv1.GetResponse, error) {
+	// atomix-codegen:begin-user-code Get
+	// TODO: implement Get
+	return nil, status.Error(codes.Unimplemented, "Get not implemented")
+	// atomix-codegen:end-user-code Get
+}
+
+var _ mapv1.MapServer = (*mapServer)(nil)
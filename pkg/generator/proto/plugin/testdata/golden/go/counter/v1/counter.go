@@ -0,0 +1,41 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by atomix-codegen dev. DO NOT EDIT.
+
+package counterv1
+
+import (
+	"context"
+	"strings"
+)
+
+// CounterMethods lists the methods of the Counter service
+var CounterMethods = []string{
+	"Increment",
+	"Get",
+	"Watch",
+	"Batch",
+	"Sync",
+}
+
+// CounterMethod returns the name of the given method in lower case
+func CounterMethod(ctx context.Context, name string) string {
+	if name == "Increment" {
+		return strings.ToLower(name)
+	}
+	if name == "Get" {
+		return strings.ToLower(name)
+	}
+	if name == "Watch" {
+		return strings.ToLower(name)
+	}
+	if name == "Batch" {
+		return strings.ToLower(name)
+	}
+	if name == "Sync" {
+		return strings.ToLower(name)
+	}
+	return ""
+}
@@ -0,0 +1,25 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by atomix-codegen dev. DO NOT EDIT.
+
+package v1
+
+import (
+	"context"
+	"strings"
+)
+
+// EchoMethods lists the methods of the Echo service
+var EchoMethods = []string{
+	"Echo",
+}
+
+// EchoMethod returns the name of the given method in lower case
+func EchoMethod(ctx context.Context, name string) string {
+	if name == "Echo" {
+		return strings.ToLower(name)
+	}
+	return ""
+}
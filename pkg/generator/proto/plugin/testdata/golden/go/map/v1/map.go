@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by atomix-codegen dev. DO NOT EDIT.
+
+package mapv1
+
+import (
+	"context"
+	"strings"
+)
+
+// MapMethods lists the methods of the Map service
+var MapMethods = []string{
+	"Put",
+	"Get",
+}
+
+// MapMethod returns the name of the given method in lower case
+func MapMethod(ctx context.Context, name string) string {
+	if name == "Put" {
+		return strings.ToLower(name)
+	}
+	if name == "Get" {
+		return strings.ToLower(name)
+	}
+	return ""
+}
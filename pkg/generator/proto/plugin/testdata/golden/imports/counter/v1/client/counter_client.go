@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by atomix-codegen dev. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+
+	counterv1 "github.com/atomix/codegen/testdata/counter/v1"
+)
+
+// CounterClient calls the Counter service
+type CounterClient struct {
+	client counterv1.CounterClient
+}
+
+// Increment calls the Increment method
+func (c *CounterClient) Increment(ctx context.Context, request *counterv1.IncrementRequest) (*counterv1.IncrementResponse, error) {
+	return c.client.Increment(ctx, request)
+}
+
+// Get calls the Get method
+func (c *CounterClient) Get(ctx context.Context, request *counterv1.GetRequest) (*counterv1.GetResponse, error) {
+	return c.client.Get(ctx, request)
+}
@@ -0,0 +1,23 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by atomix-codegen dev. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+
+	"github.com/atomix/codegen/testdata/echo/v1"
+)
+
+// EchoClient calls the Echo service
+type EchoClient struct {
+	client v1.EchoClient
+}
+
+// Echo calls the Echo method
+func (c *EchoClient) Echo(ctx context.Context, request *v1.EchoRequest) (*v1.EchoResponse, error) {
+	return c.client.Echo(ctx, request)
+}
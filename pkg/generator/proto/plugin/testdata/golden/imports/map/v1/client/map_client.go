@@ -0,0 +1,28 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by atomix-codegen dev. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+
+	mapv1 "github.com/atomix/codegen/testdata/map/v1"
+)
+
+// MapClient calls the Map service
+type MapClient struct {
+	client mapv1.MapClient
+}
+
+// Put calls the Put method
+func (c *MapClient) Put(ctx context.Context, request *mapv1.PutRequest) (*mapv1.PutResponse, error) {
+	return c.client.Put(ctx, request)
+}
+
+// Get calls the Get method
+func (c *MapClient) Get(ctx context.Context, request *mapv1.GetRequest) (*mapv1.GetResponse, error) {
+	return c.client.Get(ctx, request)
+}
@@ -0,0 +1,21 @@
+{{- /*
+SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+
+SPDX-License-Identifier: Apache-2.0
+*/ -}}
+{{- $pkg := import .Service.Package.Path .Service.Package.Name -}}
+package {{ package (printf "%s/client" .Service.Package.Path) }}
+
+// {{ .Service.Name }}Client calls the {{ .Service.Name }} service
+type {{ .Service.Name }}Client struct {
+	client {{ $pkg }}.{{ .Service.Name }}Client
+}
+{{- range .Service.Methods }}
+{{- if and .Request.IsUnary .Response.IsUnary }}
+
+// {{ .Name }} calls the {{ .Name }} method
+func (c *{{ $.Service.Name }}Client) {{ .Name }}(ctx {{ qualify "context" "Context" }}, request *{{ goType .Request.Type }}) (*{{ goType .Response.Type }}, error) {
+	return c.client.{{ .Name }}(ctx, request)
+}
+{{- end }}
+{{- end }}
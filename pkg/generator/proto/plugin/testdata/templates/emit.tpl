@@ -0,0 +1,23 @@
+{{- /*
+SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+
+SPDX-License-Identifier: Apache-2.0
+*/ -}}
+{{- $dir := .Service.File.Path | dir -}}
+{{- $name := .Service.Name | toSnake -}}
+{{- emit (printf "%s/%s_server.txt" $dir $name) "server" .Service -}}
+{{- emit (printf "%s/%s_client.txt" $dir $name) "client" .Service -}}
+
+{{- define "server" -}}
+server {{ .Name }}
+{{- range .Methods }}
+  handles {{ .Name }}
+{{- end }}
+{{ end -}}
+
+{{- define "client" -}}
+client {{ .Name }}
+{{- range .Methods }}
+  calls {{ .Name }}
+{{- end }}
+{{ end -}}
@@ -0,0 +1,115 @@
+{{- /*
+SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+
+SPDX-License-Identifier: Apache-2.0
+*/ -}}
+{{- define "type" -}}
+{{ .Name }} (file={{ .File.Name }} package={{ .Package.Name }}
+{{- with .FullName }} full-name={{ . }}{{ end }}
+{{- with .GoImportPath }} go-import={{ . }}{{ end }}
+{{- if not .IsEnumValue }} go-type={{ goType . }}{{ end }}
+{{- if .IsScalar }} scalar{{ end }}
+{{- if .IsCast }} cast{{ end }}
+{{- if .IsMessage }} message{{ end }}
+{{- if .IsPointer }} pointer{{ end }}
+{{- if .IsRepeated }} repeated{{ end }}
+{{- if .IsEnum }} enum{{ end }}
+{{- if .IsEnumValue }} enum-value{{ end }}
+{{- if .IsBytes }} bytes{{ end }}
+{{- if .IsString }} string{{ end }}
+{{- if .IsInt32 }} int32{{ end }}
+{{- if .IsInt64 }} int64{{ end }}
+{{- if .IsUint32 }} uint32{{ end }}
+{{- if .IsUint64 }} uint64{{ end }}
+{{- if .IsFloat }} float{{ end }}
+{{- if .IsDouble }} double{{ end }}
+{{- if .IsBool }} bool{{ end }}
+{{- with .WellKnown }} well-known={{ .Name }} go={{ .GoType }}{{ if .GoImportPath }} import={{ .GoImportPath }}{{ end }}{{ if .IsStdType }} std{{ end }}{{ end }}
+{{- if .IsMap }} map key=[{{ template "type" .KeyType }}] value=[{{ template "type" .ValueType }}]{{ end }}
+{{- if .Values }} values=[{{ range $i, $v := .Values }}{{ if $i }} {{ end }}{{ $v.Name }}{{ end }}]{{ end }})
+{{- end -}}
+
+{{- define "fieldref" -}}
+{{ range $i, $p := .Field.Path }}{{ if $i }}.{{ end }}{{ $p.Name }}{{ end }} (field {{ .Field.Index }} #{{ .Field.Number }} {{ .Field.Name }}): {{ template "type" .Field.Type }}
+{{- end -}}
+
+{{- define "message" -}}
+message {{ template "type" .Type }}
+{{- with .Comment }}
+  comment: {{ printf "%q" . }}
+{{- end }}
+{{- range .Fields }}
+  field {{ .Index }} #{{ .Number }} {{ .Name }} ({{ range .Path }}{{ .Name }}{{ end }}): {{ template "type" .Type }}
+  {{- if .HasPresence }} has-presence{{ end }}
+  {{- if .IsOptional }} optional{{ end }}
+  {{- with .Oneof }} oneof={{ .Name }} siblings=[{{ range $i, $f := .Fields }}{{ if $i }} {{ end }}{{ $f.Name }}{{ end }}]{{ end }}
+  {{- with .Message }} embeds={{ .Type.Name }}{{ end }}
+{{- end }}
+{{- range .Oneofs }}
+  oneof {{ .Index }} {{ .Name }}: [{{ range $i, $f := .Fields }}{{ if $i }} {{ end }}{{ $f.Name }}{{ end }}]
+{{- end }}
+{{- end -}}
+
+{{- define "enum" -}}
+enum {{ template "type" .Type }}
+{{- with .Comment }}
+  comment: {{ printf "%q" . }}
+{{- end }}
+{{- range .Values }}
+  value {{ .Number }} {{ .Type.Name }}{{ with .Comment }} comment={{ printf "%q" . }}{{ end }}
+{{- end }}
+{{- end -}}
+
+{{- define "service" -}}
+service {{ .Name }} (file={{ .File.Name }} package={{ .Package.Name }} primitive={{ .PrimitiveType }})
+{{- with .Comment }}
+  comment: {{ printf "%q" . }}
+{{- end }}
+{{- range .Methods }}
+  method {{ .Index }} {{ .Name }}
+  {{- if .IsCommand }} command{{ end }}
+  {{- if .IsQuery }} query{{ end }}
+  {{- if .IsAsync }} async{{ end }}
+  {{- with .Comment }}
+    comment: {{ printf "%q" . }}
+  {{- end }}
+    request: {{ .Request.Type.Name }}{{ if .Request.IsUnary }} unary{{ end }}{{ if .Request.IsStream }} stream{{ end }}
+  {{- with .Request.Headers }}
+    request headers: {{ template "fieldref" . }}
+  {{- end }}
+  {{- with .Request.PartitionKey }}
+    request partition key: {{ template "fieldref" . }}
+  {{- end }}
+    response: {{ .Response.Type.Name }}{{ if .Response.IsUnary }} unary{{ end }}{{ if .Response.IsStream }} stream{{ end }}
+  {{- with .Response.Headers }}
+    response headers: {{ template "fieldref" . }}
+  {{- end }}
+{{- end }}
+{{- end -}}
+
+{{- define "file" -}}
+file {{ .File.Path }} (package={{ .Package.Name }} path={{ .Package.Path }})
+{{- range .Services }}
+{{ template "service" . }}
+{{- end }}
+{{- range .Messages }}
+{{ template "message" . }}
+{{- end }}
+{{- range .Enums }}
+{{ template "enum" . }}
+{{- end }}
+{{- end -}}
+
+values:{{ range $key, $value := .Values }} {{ $key }}={{ $value }}{{ end }}
+{{ if .Files -}}
+{{ range .Files }}{{ template "file" . }}
+{{ end -}}
+{{ else if .File.File.Name -}}
+{{ template "file" .File }}
+{{ else if .Service.Name -}}
+{{ template "service" .Service }}
+{{ else if .Message.Type.Name -}}
+{{ template "message" .Message }}
+{{ else if .Enum.Type.Name -}}
+{{ template "enum" .Enum }}
+{{ end -}}
@@ -0,0 +1,27 @@
+{{- /*
+SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+
+SPDX-License-Identifier: Apache-2.0
+*/ -}}
+package {{ .Service.Package.Name }}
+
+import (
+	"strings"
+	"fmt"
+	"context"
+)
+
+// {{ .Service.Name }}Methods lists the methods of the {{ .Service.Name }} service
+var {{ .Service.Name }}Methods = []string{
+{{- range .Service.Methods }}
+{{ .Name | quote }},
+{{- end }}
+}
+
+// {{ .Service.Name }}Method returns the name of the given method in lower case
+func {{ .Service.Name }}Method(ctx context.Context, name string) string {
+{{- range .Service.Methods }}
+    if name == {{ .Name | quote }} { return strings.ToLower(name) }
+{{- end }}
+  return ""
+}
@@ -4,14 +4,36 @@
 
 package template
 
+import "github.com/atomix/codegen/pkg/generator/output"
+
 type Config struct {
-	Templates []TemplateConfig `yaml:"templates,omitempty"`
+	Templates   []TemplateConfig `yaml:"templates,omitempty"`
+	Boilerplate string           `yaml:"boilerplate,omitempty"`
 }
 
 type TemplateConfig struct {
-	Name   string       `yaml:"name"`
-	Path   string       `yaml:"path"`
-	Output OutputConfig `yaml:"output,omitempty"`
+	Name    string        `yaml:"name"`
+	Path    string        `yaml:"path"`
+	Output  OutputConfig  `yaml:"output,omitempty"`
+	Policy  output.Policy `yaml:"policy,omitempty"`
+	Content string        `yaml:"-"`
+}
+
+// TemplatePath returns the path of the template
+func (c *TemplateConfig) TemplatePath() string {
+	return c.Path
+}
+
+// SetContent sets the content of the template, which is otherwise read from the template path
+func (c *TemplateConfig) SetContent(content string) {
+	c.Content = content
+}
+
+// SetDefaultPolicy sets the write policy of the template's output, unless the policy is already set
+func (c *TemplateConfig) SetDefaultPolicy(policy output.Policy) {
+	if c.Policy == "" {
+		c.Policy = policy
+	}
 }
 
 type OutputConfig struct {
@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/atomix/codegen/pkg/generator/output"
+	"go/scanner"
+	"golang.org/x/tools/imports"
+	"path/filepath"
+)
+
+// formatContextLines is the number of lines of generated output surrounding a syntax error to include in errors
+const formatContextLines = 2
+
+// Format formats the generated file at the given path as gofmt and goimports would, removing unused
+// imports and grouping and sorting imports. Files other than Go sources are returned unchanged. Missing
+// imports are never added, since resolving them depends on the packages installed on the machine running
+// the generator; templates declare their imports, e.g. with the "import" and "qualify" functions.
+func Format(path string, src []byte) ([]byte, error) {
+	if filepath.Ext(path) != ".go" {
+		return src, nil
+	}
+	out, err := imports.Process(path, output.PruneImports(path, src, nil), &imports.Options{
+		Comments:   true,
+		TabIndent:  true,
+		TabWidth:   8,
+		FormatOnly: true,
+	})
+	if err != nil {
+		return nil, newFormatError(path, src, err)
+	}
+	return out, nil
+}
+
+// newFormatError returns an error describing a syntax error in generated output, including the
+// lines of output surrounding the error since the output lines do not match the template lines
+func newFormatError(path string, src []byte, err error) error {
+	var errs scanner.ErrorList
+	if !errors.As(err, &errs) || len(errs) == 0 {
+		return fmt.Errorf("failed to format %s: %w", path, err)
+	}
+
+	line := errs[0].Pos.Line
+	lines := bytes.Split(src, []byte("\n"))
+	var buf bytes.Buffer
+	for i := line - formatContextLines; i <= line+formatContextLines; i++ {
+		if i < 1 || i > len(lines) {
+			continue
+		}
+		marker := " "
+		if i == line {
+			marker = ">"
+		}
+		fmt.Fprintf(&buf, "\n%s %4d | %s", marker, i, lines[i-1])
+	}
+	return fmt.Errorf("failed to format %s: syntax error at output line %d: %s%s", path, line, errs[0].Msg, buf.String())
+}
@@ -51,6 +51,10 @@ func toLowerCamelCase(value string) string {
 	return strcase.ToLowerCamel(value)
 }
 
+func toKebabCase(value string) string {
+	return strcase.ToKebab(value)
+}
+
 func toLowerCase(value string) string {
 	return strings.ToLower(value)
 }
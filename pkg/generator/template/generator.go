@@ -5,7 +5,9 @@
 package template
 
 import (
-	"os"
+	"bytes"
+	"fmt"
+	"github.com/atomix/codegen/pkg/generator/output"
 	"path/filepath"
 )
 
@@ -24,43 +26,61 @@ type Generator struct {
 }
 
 func (g *Generator) Generate(values interface{}) error {
+	boilerplate, err := ReadBoilerplate(g.Config.Boilerplate)
+	if err != nil {
+		return err
+	}
 	for _, template := range g.Config.Templates {
-		if err := NewTemplate(g, template).Generate(values); err != nil {
+		if err := NewTemplate(g, template, boilerplate).Generate(values); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func NewTemplate(parent *Generator, template TemplateConfig) *TemplateGenerator {
+func NewTemplate(parent *Generator, template TemplateConfig, boilerplate string) *TemplateGenerator {
 	return &TemplateGenerator{
-		Generator: parent,
-		Template:  template,
+		Generator:   parent,
+		Template:    template,
+		Boilerplate: boilerplate,
 	}
 }
 
 type TemplateGenerator struct {
 	*Generator
-	Template TemplateConfig
+	Template    TemplateConfig
+	Boilerplate string
 }
 
 func (g *TemplateGenerator) Generate(values interface{}) error {
-	err := os.MkdirAll(filepath.Dir(g.Template.Output.Path), 0755)
-	if err != nil {
-		return err
+	imports := NewImports()
+	template := New(filepath.Base(g.Template.Path)).Funcs(imports.Funcs())
+	var err error
+	if g.Template.Content != "" {
+		template, err = template.Parse(g.Template.Content)
+	} else {
+		template, err = template.ParseFiles(g.Template.Path)
 	}
-	template, err := New(filepath.Base(g.Template.Path)).ParseFiles(g.Template.Path)
 	if err != nil {
+		return fmt.Errorf("template %s: %w", g.Template.Path, err)
+	}
+	params := Params{
+		Values: values,
+	}
+	var buf bytes.Buffer
+	buf.WriteString(Header(g.Template.Output.Path, g.Boilerplate))
+	if err := template.Execute(&buf, params); err != nil {
 		return err
 	}
-	file, err := os.Create(g.Template.Output.Path)
+	content, err := imports.Pop().Apply(g.Template.Output.Path, buf.Bytes())
 	if err != nil {
-		return err
+		return fmt.Errorf("template %s: %w", g.Template.Path, err)
 	}
-	params := Params{
-		Values: values,
+	content, err = Format(g.Template.Output.Path, content)
+	if err != nil {
+		return fmt.Errorf("template %s: %w", g.Template.Path, err)
 	}
-	return template.Execute(file, params)
+	return output.Write(g.Template.Output.Path, content, g.Template.Policy)
 }
 
 type Params struct {
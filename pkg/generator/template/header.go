@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"fmt"
+	"github.com/atomix/codegen/pkg/version"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// ReadBoilerplate reads the license boilerplate at the given path, returning an empty boilerplate if no path is set
+func ReadBoilerplate(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read boilerplate: %w", err)
+	}
+	return string(bytes), nil
+}
+
+// Header returns the header to prepend to the generated file at the given path, or an empty string
+// if the file type does not get a header. The header consists of the license boilerplate, if any, followed
+// by a "Code generated ... DO NOT EDIT." line recording the generator version.
+func Header(path string, boilerplate string) string {
+	switch filepath.Ext(path) {
+	case ".go":
+		var header strings.Builder
+		if boilerplate = strings.TrimSpace(boilerplate); boilerplate != "" {
+			header.WriteString(boilerplate)
+			header.WriteString("\n\n")
+		}
+		header.WriteString(fmt.Sprintf("// Code generated by atomix-codegen %s. DO NOT EDIT.\n\n", version.String()))
+		return header.String()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,249 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"golang.org/x/tools/go/ast/astutil"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+var versionSuffix = regexp.MustCompile(`^v[0-9]+$`)
+
+// GoQualifier is implemented by template parameters that refer to a declaration in a Go package
+type GoQualifier interface {
+	// GoPackage returns the import path and name of the package declaring the value, or an empty
+	// path if the declaration does not need to be qualified
+	GoPackage() (path string, name string)
+	// GoIdent returns the unqualified name of the declaration
+	GoIdent() string
+}
+
+// GoTyper is implemented by template parameters that describe a Go type
+type GoTyper interface {
+	// GoType returns the Go type expression, qualifying declarations with the given function
+	GoType(qualify func(GoQualifier) string) string
+}
+
+// NewImports creates a new import tracker
+func NewImports() *Imports {
+	return &Imports{
+		files: []*FileImports{newFileImports()},
+	}
+}
+
+// Imports tracks the Go packages referenced by the files rendered by a template. Files rendered
+// while another file is being rendered, e.g. by the "emit" function, are tracked separately.
+type Imports struct {
+	files []*FileImports
+}
+
+// Funcs returns the template functions that import packages into the file being rendered:
+//
+//	{{ package "github.com/atomix/sdk/pkg/driver" }} sets the package of the file and returns the package name
+//	{{ import "github.com/atomix/sdk/pkg/errors" }} adds the import and returns the package name
+//	{{ qualify "github.com/atomix/sdk/pkg/errors" "NewNotFound" }} adds the import and returns "errors.NewNotFound"
+//	{{ qualify .Type }} adds the import for a type declared in another package and returns its qualified name
+//	{{ goType .Type }} returns the Go type expression for a type, e.g. "map[string]*mapv1.Entry"
+//
+// Declarations in the package of the file are not qualified.
+func (i *Imports) Funcs() template.FuncMap {
+	return template.FuncMap{
+		"package": func(path string, name ...string) (string, error) {
+			if len(name) > 1 {
+				return "", fmt.Errorf("package %s: too many names", path)
+			}
+			return i.current().SetPackage(path, strings.Join(name, "")), nil
+		},
+		"import": func(path string, name ...string) (string, error) {
+			if len(name) > 1 {
+				return "", fmt.Errorf("import %s: too many names", path)
+			}
+			return i.current().Add(path, strings.Join(name, "")), nil
+		},
+		"qualify": func(args ...interface{}) (string, error) {
+			switch len(args) {
+			case 1:
+				qualifier, ok := args[0].(GoQualifier)
+				if !ok {
+					return "", fmt.Errorf("qualify: %T does not refer to a Go declaration", args[0])
+				}
+				return i.current().Qualify(qualifier), nil
+			case 2:
+				path, ok := args[0].(string)
+				if !ok {
+					return "", fmt.Errorf("qualify: import path must be a string, not %T", args[0])
+				}
+				name, ok := args[1].(string)
+				if !ok {
+					return "", fmt.Errorf("qualify: name must be a string, not %T", args[1])
+				}
+				return i.current().qualify(path, "", name), nil
+			default:
+				return "", fmt.Errorf("qualify: expected 1 or 2 arguments, got %d", len(args))
+			}
+		},
+		"goType": func(value interface{}) (string, error) {
+			typer, ok := value.(GoTyper)
+			if !ok {
+				return "", fmt.Errorf("goType: %T does not describe a Go type", value)
+			}
+			return typer.GoType(i.current().Qualify), nil
+		},
+	}
+}
+
+// Push starts tracking imports for a file rendered while rendering the current file
+func (i *Imports) Push() {
+	i.files = append(i.files, newFileImports())
+}
+
+// Pop stops tracking imports for the current file and returns them
+func (i *Imports) Pop() *FileImports {
+	file := i.current()
+	if len(i.files) > 1 {
+		i.files = i.files[:len(i.files)-1]
+	} else {
+		i.files[0] = newFileImports()
+	}
+	return file
+}
+
+func (i *Imports) current() *FileImports {
+	return i.files[len(i.files)-1]
+}
+
+func newFileImports() *FileImports {
+	return &FileImports{
+		names: make(map[string]string),
+		paths: make(map[string]string),
+	}
+}
+
+// FileImports is the set of packages imported by a single generated file
+type FileImports struct {
+	pkg   string
+	names map[string]string
+	paths map[string]string
+}
+
+// SetPackage sets the import path of the file's own package, whose declarations are not qualified,
+// and returns the package name. If no name is given the name is derived from the import path.
+func (f *FileImports) SetPackage(path string, name string) string {
+	f.pkg = path
+	if name == "" {
+		name = packageName(path)
+	}
+	return name
+}
+
+// Add imports the package with the given path and returns the name by which the file refers to it.
+// If no name is given the name is derived from the import path. Names are made unique within the file.
+func (f *FileImports) Add(path string, name string) string {
+	if name, ok := f.names[path]; ok {
+		return name
+	}
+	if name == "" {
+		name = packageName(path)
+	}
+	unique := name
+	for i := 2; ; i++ {
+		if _, ok := f.paths[unique]; !ok {
+			break
+		}
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	f.names[path] = unique
+	f.paths[unique] = path
+	return unique
+}
+
+// Qualify imports the package declaring the given value and returns its qualified name
+func (f *FileImports) Qualify(qualifier GoQualifier) string {
+	path, name := qualifier.GoPackage()
+	return f.qualify(path, name, qualifier.GoIdent())
+}
+
+func (f *FileImports) qualify(path string, pkg string, name string) string {
+	if path == "" || path == f.pkg {
+		return name
+	}
+	return fmt.Sprintf("%s.%s", f.Add(path, pkg), name)
+}
+
+// Apply adds the imports to the import block of the Go source file at the given path. Sources that are
+// not Go files or that cannot be parsed are returned unchanged, leaving syntax errors to be reported by Format.
+func (f *FileImports) Apply(filePath string, src []byte) ([]byte, error) {
+	if len(f.names) == 0 || filepath.Ext(filePath) != ".go" {
+		return src, nil
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filePath, src, parser.ParseComments)
+	if err != nil {
+		return src, nil
+	}
+
+	paths := make([]string, 0, len(f.names))
+	for importPath := range f.names {
+		paths = append(paths, importPath)
+	}
+	sort.Strings(paths)
+	for _, importPath := range paths {
+		// The name is omitted where it's implied by the path, unless the template already imports the path by name
+		name := f.names[importPath]
+		if name == path.Base(importPath) && !hasNamedImport(file, importPath) {
+			name = ""
+		}
+		astutil.AddNamedImport(fset, file, name, importPath)
+	}
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// hasNamedImport returns whether the file imports the given path with an explicit name
+func hasNamedImport(file *ast.File, importPath string) bool {
+	for _, spec := range file.Imports {
+		if spec.Name != nil && strings.Trim(spec.Path.Value, `"`) == importPath {
+			return true
+		}
+	}
+	return false
+}
+
+// packageName derives a package name from the last element of the import path, skipping major version suffixes
+func packageName(importPath string) string {
+	name := path.Base(importPath)
+	if versionSuffix.MatchString(name) && path.Dir(importPath) != "." {
+		name = path.Base(path.Dir(importPath))
+	}
+	name = strings.TrimPrefix(name, "go-")
+	name = strings.TrimSuffix(name, "-go")
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "pkg" + name
+	}
+	return name
+}
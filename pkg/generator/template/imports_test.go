@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+)
+
+func TestFileImportsApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		path     string
+		pkg      string
+		expected map[string]string
+	}{
+		{
+			name:     "implied name",
+			src:      "package driver\n\nvar _ v1.CounterServer\n",
+			path:     "github.com/example/api/counter/v1",
+			pkg:      "v1",
+			expected: map[string]string{"github.com/example/api/counter/v1": ""},
+		},
+		{
+			name:     "explicit name",
+			src:      "package driver\n\nvar _ counterv1.CounterServer\n",
+			path:     "github.com/example/api/counter/v1",
+			pkg:      "counterv1",
+			expected: map[string]string{"github.com/example/api/counter/v1": "counterv1"},
+		},
+		{
+			name:     "already imported by name",
+			src:      "package driver\n\nimport v1 \"github.com/example/api/counter/v1\"\n\nvar _ v1.CounterServer\n",
+			path:     "github.com/example/api/counter/v1",
+			pkg:      "v1",
+			expected: map[string]string{"github.com/example/api/counter/v1": "v1"},
+		},
+		{
+			name:     "already imported",
+			src:      "package driver\n\nimport \"github.com/example/api/counter/v1\"\n\nvar _ v1.CounterServer\n",
+			path:     "github.com/example/api/counter/v1",
+			pkg:      "v1",
+			expected: map[string]string{"github.com/example/api/counter/v1": ""},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			imports := newFileImports()
+			imports.Add(test.path, test.pkg)
+			out, err := imports.Apply("counter.go", []byte(test.src))
+			if err != nil {
+				t.Fatal(err)
+			}
+			file, err := parser.ParseFile(token.NewFileSet(), "counter.go", out, parser.ImportsOnly)
+			if err != nil {
+				t.Fatal(err)
+			}
+			actual := make(map[string]string)
+			for _, spec := range file.Imports {
+				path, err := strconv.Unquote(spec.Path.Value)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if _, ok := actual[path]; ok {
+					t.Fatalf("%s is imported more than once:\n%s", path, out)
+				}
+				actual[path] = ""
+				if spec.Name != nil {
+					actual[path] = spec.Name.Name
+				}
+			}
+			if len(actual) != len(test.expected) {
+				t.Fatalf("expected imports %v, got %v", test.expected, actual)
+			}
+			for path, name := range test.expected {
+				if actualName, ok := actual[path]; !ok || actualName != name {
+					t.Errorf("expected import %q named %q, got %v", path, name, actual)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"errors"
+	"fmt"
+	"github.com/atomix/codegen/pkg/generator/output"
+	"io/fs"
+	"os"
+)
+
+// NewLoader creates a new template loader for the given default templates, e.g. templates embedded in
+// a generator binary. If an override directory is set, templates in the directory replace the default
+// templates with the same names.
+func NewLoader(defaults fs.FS, overrideDir string) *Loader {
+	loader := &Loader{
+		defaults:    defaults,
+		overrideDir: overrideDir,
+	}
+	if overrideDir != "" {
+		loader.overrides = os.DirFS(overrideDir)
+	}
+	return loader
+}
+
+// Loader reads templates, overlaying the override directory on the default templates
+type Loader struct {
+	defaults    fs.FS
+	overrides   fs.FS
+	overrideDir string
+	scaffolding map[string]bool
+}
+
+// Scaffold marks the named templates as scaffolding, e.g. Makefiles and go.mod files. Scaffolding files are
+// only created by default, since developers customize them once they're generated.
+func (l *Loader) Scaffold(names ...string) *Loader {
+	if l.scaffolding == nil {
+		l.scaffolding = make(map[string]bool)
+	}
+	for _, name := range names {
+		l.scaffolding[name] = true
+	}
+	return l
+}
+
+// Source is a template configuration whose content is read by a Loader
+type Source interface {
+	// TemplatePath returns the path of the template relative to the template directories
+	TemplatePath() string
+	// SetContent sets the content of the template
+	SetContent(content string)
+	// SetDefaultPolicy sets the write policy of the template's output, unless the policy is already set
+	SetDefaultPolicy(policy output.Policy)
+}
+
+// Load reads the content of the given templates. Templates in the override directory replace the
+// default templates with the same names.
+func (l *Loader) Load(sources ...Source) error {
+	for _, source := range sources {
+		content, err := l.Read(source.TemplatePath())
+		if err != nil {
+			return err
+		}
+		source.SetContent(content)
+		if l.scaffolding[source.TemplatePath()] {
+			source.SetDefaultPolicy(output.CreateOnlyPolicy)
+		}
+	}
+	return nil
+}
+
+// Read reads the named template, preferring the override directory
+func (l *Loader) Read(name string) (string, error) {
+	if l.overrides != nil {
+		bytes, err := fs.ReadFile(l.overrides, name)
+		if err == nil {
+			return string(bytes), nil
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return "", fmt.Errorf("failed to read template %s from %s: %w", name, l.overrideDir, err)
+		} else if _, err := os.Stat(l.overrideDir); err != nil {
+			return "", fmt.Errorf("failed to read templates: %w", err)
+		}
+	}
+	bytes, err := fs.ReadFile(l.defaults, name)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: 2022-present Open Networking Foundation <info@opennetworking.org>
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"github.com/atomix/codegen/pkg/generator/output"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoaderLoad(t *testing.T) {
+	defaults := fstest.MapFS{
+		"go.mod.tpl":   {Data: []byte("module {{ .Values.Module.Path }}\n")},
+		"Makefile.tpl": {Data: []byte("build:\n")},
+	}
+	overrideDir := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(overrideDir, "Makefile.tpl"), []byte("build: test\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		overrideDir string
+		expected    map[string]string
+		err         bool
+	}{
+		{
+			name: "defaults",
+			expected: map[string]string{
+				"go.mod.tpl":   "module {{ .Values.Module.Path }}\n",
+				"Makefile.tpl": "build:\n",
+			},
+		},
+		{
+			name:        "overrides",
+			overrideDir: overrideDir,
+			expected: map[string]string{
+				"go.mod.tpl":   "module {{ .Values.Module.Path }}\n",
+				"Makefile.tpl": "build: test\n",
+			},
+		},
+		{
+			name:        "missing override directory",
+			overrideDir: filepath.Join(overrideDir, "missing"),
+			err:         true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			templates := []TemplateConfig{
+				{Name: "go.mod", Path: "go.mod.tpl"},
+				{Name: "Makefile", Path: "Makefile.tpl"},
+			}
+			err := NewLoader(defaults, test.overrideDir).Load(&templates[0], &templates[1])
+			if test.err {
+				if err == nil {
+					t.Fatal("expected an error for a missing override directory")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, template := range templates {
+				if expected := test.expected[template.Path]; template.Content != expected {
+					t.Errorf("expected %s to contain %q, got %q", template.Path, expected, template.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestLoaderScaffold(t *testing.T) {
+	defaults := fstest.MapFS{
+		"go.mod.tpl":    {Data: []byte("module {{ .Values.Module.Path }}\n")},
+		"Makefile.tpl":  {Data: []byte("build:\n")},
+		"driver.go.tpl": {Data: []byte("package driver\n")},
+	}
+	templates := []TemplateConfig{
+		{Name: "go.mod", Path: "go.mod.tpl"},
+		{Name: "Makefile", Path: "Makefile.tpl", Policy: output.AlwaysPolicy},
+		{Name: "driver", Path: "driver.go.tpl"},
+	}
+	loader := NewLoader(defaults, "").Scaffold("go.mod.tpl", "Makefile.tpl")
+	if err := loader.Load(&templates[0], &templates[1], &templates[2]); err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]output.Policy{
+		"go.mod.tpl":    output.CreateOnlyPolicy,
+		"Makefile.tpl":  output.AlwaysPolicy,
+		"driver.go.tpl": "",
+	}
+	for _, template := range templates {
+		if template.Policy != expected[template.Path] {
+			t.Errorf("expected %s to have policy %q, got %q", template.Path, expected[template.Path], template.Policy)
+		}
+	}
+}
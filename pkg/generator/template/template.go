@@ -25,6 +25,7 @@ func New(name string) *template.Template {
 		"toCamel":      toCamelCase,
 		"toLowerCamel": toLowerCamelCase,
 		"toSnake":      toSnakeCase,
+		"toKebab":      toKebabCase,
 		"lower":        toLowerCase,
 		"upper":        toUpperCase,
 		"upperFirst":   upperFirst,
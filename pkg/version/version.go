@@ -4,6 +4,8 @@
 
 package version
 
+import "fmt"
+
 var (
 	version     string
 	commit      string
@@ -16,7 +18,11 @@ const (
 	release  = "release"
 )
 
+// Version returns the version of the build, or "dev" for development builds
 func Version() string {
+	if version == "" {
+		return "dev"
+	}
 	return version
 }
 
@@ -35,3 +41,11 @@ func IsSnapshot() bool {
 func IsRelease() bool {
 	return buildType == release
 }
+
+// String returns the version and commit of the build, e.g. "v1.2.3 (0123abc)"
+func String() string {
+	if shortCommit == "" {
+		return Version()
+	}
+	return fmt.Sprintf("%s (%s)", Version(), shortCommit)
+}